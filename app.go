@@ -6,11 +6,14 @@ import (
 	"errors" // добавлено
 	"fmt"
 	"log"
+	"slices"
 	"strings"
+	"sync"
 	"time" // уже добавлено
 	"transmission-client-go/internal/application"
 	"transmission-client-go/internal/domain"
 	"transmission-client-go/internal/infrastructure"
+	"transmission-client-go/internal/infrastructure/metainfo"
 	"transmission-client-go/internal/infrastructure/transmission"
 
 	"encoding/base64" // добавлено
@@ -23,9 +26,18 @@ import (
 type App struct {
 	ctx                 context.Context
 	service             *application.TorrentService
+	servers             *application.MultiServerService
+	poller              *application.Poller
+	policy              *application.PolicyEngine
+	rules               *application.RuleEngine
 	configService       *infrastructure.ConfigService
 	localizationService *infrastructure.LocalizationService
 	pendingTorrentFile  string
+
+	// Контекст текущего подключения: отменяется при смене профиля и завершении приложения
+	sessionMu     sync.Mutex
+	sessionCtx    context.Context
+	sessionCancel context.CancelFunc
 }
 
 // Error constants
@@ -43,9 +55,22 @@ func NewApp() *App {
 		locService = &infrastructure.LocalizationService{}
 	}
 
-	return &App{
+	app := &App{
 		configService:       infrastructure.NewConfigService(),
 		localizationService: locService,
+		servers:             application.NewMultiServerService(),
+	}
+	app.poller = application.NewPoller(app.emitEvent)
+	app.policy = application.NewPolicyEngine(app.emitEvent, application.DefaultPolicyInterval)
+	app.rules = application.NewRuleEngine(app.emitEvent, application.DefaultRuleInterval)
+	app.poller.OnUpdate(app.rules.HandleUpdate)
+	return app
+}
+
+// emitEvent отправляет событие во фронтенд, если приложение уже запущено
+func (a *App) emitEvent(name string, data any) {
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, name, data)
 	}
 }
 
@@ -53,6 +78,12 @@ func NewApp() *App {
 func (a *App) startup(ctx context.Context) {
 	a.ctx = ctx
 
+	// Запускаем фоновый опрос сервера, данные приходят во фронтенд событиями
+	go a.poller.Run(ctx)
+	// Правила обслуживания раздач применяются независимо от опроса интерфейса
+	go a.policy.Run(ctx)
+	go a.rules.Run(ctx)
+
 	// Независимо от состояния сервиса, через 1 сек решил отправить событие, если pendingTorrentFile установлен.
 	go func() {
 		time.Sleep(1 * time.Second) // задержка 1 секунда
@@ -77,10 +108,52 @@ func (a *App) startup(ctx context.Context) {
 	}
 }
 
+// shutdown is called when the app is closing. Cancels all in-flight requests
+func (a *App) shutdown(ctx context.Context) {
+	a.sessionMu.Lock()
+	defer a.sessionMu.Unlock()
+
+	if a.sessionCancel != nil {
+		a.sessionCancel()
+	}
+}
+
+// resetSession отменяет запросы предыдущего подключения и создает новый контекст
+func (a *App) resetSession() {
+	a.sessionMu.Lock()
+	defer a.sessionMu.Unlock()
+
+	if a.sessionCancel != nil {
+		a.sessionCancel()
+	}
+
+	parent := a.ctx
+	if parent == nil {
+		parent = context.Background()
+	}
+	a.sessionCtx, a.sessionCancel = context.WithCancel(parent)
+}
+
+// requestContext возвращает контекст для запросов к Transmission в рамках текущего подключения
+func (a *App) requestContext() context.Context {
+	a.sessionMu.Lock()
+	defer a.sessionMu.Unlock()
+
+	if a.sessionCtx == nil {
+		return context.Background()
+	}
+	return a.sessionCtx
+}
+
 // Initialize initializes the transmission client with the given configuration
 func (a *App) Initialize(configJson string) error {
-	var config domain.Config
-	if err := json.Unmarshal([]byte(configJson), &config); err != nil {
+	saved, err := a.configService.LoadConfig()
+	if err != nil {
+		saved = nil
+	}
+
+	config, err := mergeConfig(saved, configJson)
+	if err != nil {
 		return err
 	}
 
@@ -90,27 +163,290 @@ func (a *App) Initialize(configJson string) error {
 	}
 
 	// Save the configuration
-	if err := a.configService.SaveConfig(&config); err != nil {
+	if err := a.configService.SaveConfig(config); err != nil {
 		return fmt.Errorf("failed to save config: %w", err)
 	}
 
-	// Create client with config
-	client, err := transmission.NewTransmissionClient(transmission.TransmissionConfig{
-		Host:     config.Host,
-		Port:     config.Port,
-		Username: config.Username,
-		Password: config.Password,
-	})
+	return a.connect(config)
+}
+
+// mergeConfig накладывает присланные поля на сохраненную конфигурацию активного профиля.
+// Форма настроек присылает только часть параметров подключения, поэтому остальные
+// (TLS, путь RPC, таймауты, каталоги) берутся из профиля. Список профилей и правила
+// управляются отдельными методами и всегда остаются сохраненными.
+func mergeConfig(saved *domain.Config, configJson string) (*domain.Config, error) {
+	var incoming domain.Config
+	if err := json.Unmarshal([]byte(configJson), &incoming); err != nil {
+		return nil, err
+	}
+	if saved == nil {
+		return &incoming, nil
+	}
+
+	config := *saved
+	if profile := saved.FindProfile(incoming.ActiveProfile); profile != nil {
+		config.ApplyProfile(profile)
+	}
+
+	// Не даем декодеру писать в срезы сохраненной конфигурации
+	config.Profiles, config.PolicyRules, config.AutomationRules = nil, nil, nil
+	config.DownloadPaths = slices.Clone(config.DownloadPaths)
+	if err := json.Unmarshal([]byte(configJson), &config); err != nil {
+		return nil, err
+	}
+
+	config.Profiles = saved.Profiles
+	config.PolicyRules = saved.PolicyRules
+	config.AutomationRules = saved.AutomationRules
+	if config.ActiveProfile == "" {
+		config.ActiveProfile = saved.ActiveProfile
+	}
+	return &config, nil
+}
+
+// connect создает клиент Transmission и сервис торрентов для текущего профиля конфигурации
+func (a *App) connect(config *domain.Config) error {
+	client, err := transmission.NewTransmissionClient(profileTransmissionConfig(config.ConnectionProfile()))
 	if err != nil {
 		return err
 	}
 
+	// Прерываем запросы к предыдущему серверу
+	a.resetSession()
+
 	a.service = application.NewTorrentService(client)
 	// Обновляем конфигурацию в сервисе
-	a.service.UpdateConfig(&config)
+	a.service.UpdateConfig(config)
+	a.rebuildServers(config)
+	a.poller.SetSource(a.requestContext(), a.service)
+	a.policy.SetSource(a.requestContext(), a.service)
+	a.rules.SetSource(a.requestContext(), a.service)
 	return nil
 }
 
+// SetWindowVisible сообщает, видно ли окно, чтобы реже опрашивать сервер в фоне
+func (a *App) SetWindowVisible(visible bool) {
+	a.poller.SetHidden(!visible)
+}
+
+// SetPollingInterval задает интервал опроса сервера в миллисекундах
+func (a *App) SetPollingInterval(intervalMs int) error {
+	return a.poller.SetInterval(time.Duration(intervalMs) * time.Millisecond)
+}
+
+// RequestUpdate запрашивает внеочередное обновление данных после действий пользователя
+func (a *App) RequestUpdate() {
+	a.poller.Trigger()
+}
+
+// profileTransmissionConfig преобразует профиль сервера в параметры клиента Transmission
+func profileTransmissionConfig(profile domain.ServerProfile) transmission.TransmissionConfig {
+	return transmission.TransmissionConfig{
+		Host:           profile.Host,
+		Port:           profile.Port,
+		Username:       profile.Username,
+		Password:       profile.Password,
+		Scheme:         profile.Scheme,
+		RPCPath:        profile.RPCPath,
+		CACertPath:     profile.CACertPath,
+		ClientCertPath: profile.ClientCertPath,
+		ClientKeyPath:  profile.ClientKeyPath,
+		AllowInsecure:  profile.AllowInsecure,
+		ConnectTimeout: time.Duration(profile.ConnectTimeout) * time.Second,
+		RequestTimeout: time.Duration(profile.RequestTimeout) * time.Second,
+	}
+}
+
+// rebuildServers пересоздает клиенты всех профилей для объединенного просмотра
+func (a *App) rebuildServers(config *domain.Config) {
+	a.servers.Reset()
+	for i := range config.Profiles {
+		profile := &config.Profiles[i]
+		client, err := transmission.NewTransmissionClient(profileTransmissionConfig(*profile))
+		if err != nil {
+			log.Printf("failed to create client for profile %s: %v", profile.Name, err)
+			continue
+		}
+		a.servers.SetServer(profile.ID, client)
+	}
+}
+
+// updateServiceConfig передает сервису актуальную конфигурацию после изменения профилей
+func (a *App) updateServiceConfig(config *domain.Config) {
+	if a.service != nil {
+		a.service.UpdateConfig(config)
+	}
+	a.rebuildServers(config)
+}
+
+// GetPolicyRules возвращает сохраненные правила обслуживания раздач
+func (a *App) GetPolicyRules() ([]domain.PolicyRule, error) {
+	config, err := a.configService.LoadConfig()
+	if err != nil || config == nil {
+		return []domain.PolicyRule{}, err
+	}
+	return config.PolicyRules, nil
+}
+
+// SavePolicyRules сохраняет правила обслуживания раздач и сразу применяет их
+func (a *App) SavePolicyRules(rules []domain.PolicyRule) error {
+	for _, rule := range rules {
+		if err := application.ValidatePolicyRule(rule); err != nil {
+			return err
+		}
+	}
+
+	config, err := a.configService.SavePolicyRules(rules)
+	if err != nil {
+		return err
+	}
+	a.updateServiceConfig(config)
+	a.policy.Trigger()
+	return nil
+}
+
+// GetPolicyLog возвращает журнал действий, выполненных правилами
+func (a *App) GetPolicyLog() []domain.PolicyLogEntry {
+	return a.policy.Log()
+}
+
+// ClearPolicyLog очищает журнал действий правил
+func (a *App) ClearPolicyLog() {
+	a.policy.ClearLog()
+}
+
+// GetAutomationRules возвращает сохраненные правила автоматизации
+func (a *App) GetAutomationRules() ([]domain.AutomationRule, error) {
+	config, err := a.configService.LoadConfig()
+	if err != nil || config == nil {
+		return []domain.AutomationRule{}, err
+	}
+	return config.AutomationRules, nil
+}
+
+// SaveAutomationRules сохраняет правила автоматизации
+func (a *App) SaveAutomationRules(rules []domain.AutomationRule) error {
+	for _, rule := range rules {
+		if err := application.ValidateAutomationRule(rule); err != nil {
+			return err
+		}
+	}
+
+	config, err := a.configService.SaveAutomationRules(rules)
+	if err != nil {
+		return err
+	}
+	a.updateServiceConfig(config)
+	a.rules.Trigger()
+	return nil
+}
+
+// PreviewAutomationRule показывает, какие торренты сейчас подходят под правило, не выполняя действий
+func (a *App) PreviewAutomationRule(rule domain.AutomationRule) ([]domain.RuleMatch, error) {
+	if a.service == nil {
+		return nil, errors.New(ErrServiceNotInitialized)
+	}
+	return a.rules.Preview(a.requestContext(), a.service, rule)
+}
+
+// GetAutomationLog возвращает журнал срабатываний правил автоматизации
+func (a *App) GetAutomationLog() []domain.RuleLogEntry {
+	return a.rules.Log()
+}
+
+// ClearAutomationLog очищает журнал срабатываний правил автоматизации
+func (a *App) ClearAutomationLog() {
+	a.rules.ClearLog()
+}
+
+// GetAllServersTorrents возвращает объединенный список торрентов со всех профилей
+func (a *App) GetAllServersTorrents() (*domain.AggregatedTorrents, error) {
+	return a.servers.GetAllTorrents(a.requestContext())
+}
+
+// GetAllServersSessionStats возвращает статистику по каждому серверу и суммарную
+func (a *App) GetAllServersSessionStats() (*domain.AggregatedSessionStats, error) {
+	return a.servers.GetSessionStats(a.requestContext())
+}
+
+// StartServerTorrents запускает торренты на соответствующих серверах
+func (a *App) StartServerTorrents(refs []domain.TorrentRef) error {
+	return a.servers.StartTorrents(a.requestContext(), refs)
+}
+
+// StopServerTorrents останавливает торренты на соответствующих серверах
+func (a *App) StopServerTorrents(refs []domain.TorrentRef) error {
+	return a.servers.StopTorrents(a.requestContext(), refs)
+}
+
+// RemoveServerTorrents удаляет торренты на соответствующих серверах
+func (a *App) RemoveServerTorrents(refs []domain.TorrentRef, deleteData bool) error {
+	return a.servers.RemoveTorrents(a.requestContext(), refs, deleteData)
+}
+
+// VerifyServerTorrents запускает проверку торрентов на соответствующих серверах
+func (a *App) VerifyServerTorrents(refs []domain.TorrentRef) error {
+	return a.servers.VerifyTorrents(a.requestContext(), refs)
+}
+
+// GetProfiles возвращает список сохраненных профилей серверов
+func (a *App) GetProfiles() ([]domain.ServerProfile, error) {
+	return a.configService.ListProfiles()
+}
+
+// CreateProfile создает новый профиль сервера из JSON
+func (a *App) CreateProfile(profileJson string) (*domain.ServerProfile, error) {
+	var profile domain.ServerProfile
+	if err := json.Unmarshal([]byte(profileJson), &profile); err != nil {
+		return nil, err
+	}
+
+	config, created, err := a.configService.CreateProfile(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	// Если это первый профиль, он стал активным и к нему нужно подключиться
+	if a.service == nil && config.ActiveProfile == created.ID {
+		if err := a.connect(config); err != nil {
+			return created, err
+		}
+		return created, nil
+	}
+
+	a.updateServiceConfig(config)
+	return created, nil
+}
+
+// RenameProfile переименовывает профиль сервера
+func (a *App) RenameProfile(id string, name string) error {
+	config, err := a.configService.RenameProfile(id, name)
+	if err != nil {
+		return err
+	}
+	a.updateServiceConfig(config)
+	return nil
+}
+
+// DeleteProfile удаляет неактивный профиль сервера
+func (a *App) DeleteProfile(id string) error {
+	config, err := a.configService.DeleteProfile(id)
+	if err != nil {
+		return err
+	}
+	a.updateServiceConfig(config)
+	return nil
+}
+
+// SwitchProfile переключает приложение на другой профиль без перезапуска
+func (a *App) SwitchProfile(id string) error {
+	config, err := a.configService.SetActiveProfile(id)
+	if err != nil {
+		return err
+	}
+	return a.connect(config)
+}
+
 // LoadConfig loads saved configuration if it exists
 func (a *App) LoadConfig() (*domain.Config, error) {
 	return a.configService.LoadConfig()
@@ -137,7 +473,7 @@ func (a *App) GetSessionStats() (*domain.SessionStats, error) {
 	if a.service == nil {
 		return nil, errors.New(ErrServiceNotInitialized) // заменено
 	}
-	return a.service.GetSessionStats()
+	return a.service.GetSessionStats(a.requestContext())
 }
 
 // GetTorrents returns all torrents
@@ -145,27 +481,55 @@ func (a *App) GetTorrents() ([]domain.Torrent, error) {
 	if a.service == nil {
 		return nil, errors.New(ErrServiceNotInitialized)
 	}
-	return a.service.GetAllTorrents()
+	return a.service.GetAllTorrents(a.requestContext())
 }
 
-// AddTorrent adds a new torrent by URL
-func (a *App) AddTorrent(url string, downloadDir string) error {
+// AddTorrent adds a new torrent by URL with the given add options.
+// Adding a torrent that already exists returns a duplicate result instead of an error.
+func (a *App) AddTorrent(url string, options domain.AddTorrentOptions) (*domain.AddTorrentResult, error) {
 	if a.service == nil {
-		return errors.New(ErrServiceNotInitialized)
+		return nil, errors.New(ErrServiceNotInitialized)
+	}
+	result, err := a.service.AddTorrent(a.requestContext(), url, options)
+	if err != nil {
+		return nil, errors.New(a.getLocalizedError(err))
 	}
-	return a.service.AddTorrent(url, downloadDir)
+	return a.localizeAddResult(result), nil
 }
 
-// AddTorrentFile adds a torrent from a base64-encoded file
-func (a *App) AddTorrentFile(base64Content string, downloadDir string) error {
+// AddTorrentFile adds a torrent from a base64-encoded file with the given add options
+func (a *App) AddTorrentFile(base64Content string, options domain.AddTorrentOptions) (*domain.AddTorrentResult, error) {
 	if a.service == nil {
-		return errors.New(ErrServiceNotInitialized)
+		return nil, errors.New(ErrServiceNotInitialized)
 	}
 	// Add the data URL prefix if it doesn't exist
 	if !strings.HasPrefix(base64Content, "data:") {
 		base64Content = "data:application/x-bittorrent;base64," + base64Content
 	}
-	return a.service.AddTorrent(base64Content, downloadDir)
+	result, err := a.service.AddTorrent(a.requestContext(), base64Content, options)
+	if err != nil {
+		return nil, errors.New(a.getLocalizedError(err))
+	}
+	return a.localizeAddResult(result), nil
+}
+
+// localizeAddResult fills the user-facing message of an add result
+func (a *App) localizeAddResult(result *domain.AddTorrentResult) *domain.AddTorrentResult {
+	if result == nil || result.Status != domain.AddStatusDuplicate {
+		return result
+	}
+
+	language := a.currentLanguage()
+	switch {
+	case result.Name == "":
+		// Older Transmission versions do not report which torrent is already added
+		result.Message = a.localizationService.Translate("errors.duplicateTorrent", language)
+	case result.MergedTrackers > 0:
+		result.Message = a.localizationService.Translate("add.duplicateMerged", language, result.Name, result.MergedTrackers)
+	default:
+		result.Message = a.localizationService.Translate("add.duplicate", language, result.Name)
+	}
+	return result
 }
 
 // RemoveTorrent removes a torrent by ID
@@ -173,7 +537,7 @@ func (a *App) RemoveTorrent(id int64, deleteData bool) error {
 	if a.service == nil {
 		return errors.New(ErrServiceNotInitialized)
 	}
-	return a.service.RemoveTorrent(id, deleteData)
+	return a.service.RemoveTorrent(a.requestContext(), id, deleteData)
 }
 
 // StartTorrents starts the selected torrents
@@ -181,7 +545,7 @@ func (a *App) StartTorrents(ids []int64) error {
 	if a.service == nil {
 		return errors.New(ErrServiceNotInitialized)
 	}
-	return a.service.StartTorrents(ids)
+	return a.service.StartTorrents(a.requestContext(), ids)
 }
 
 // StopTorrents stops the selected torrents
@@ -189,7 +553,107 @@ func (a *App) StopTorrents(ids []int64) error {
 	if a.service == nil {
 		return errors.New(ErrServiceNotInitialized)
 	}
-	return a.service.StopTorrents(ids)
+	return a.service.StopTorrents(a.requestContext(), ids)
+}
+
+// SetTorrentLabels replaces labels of the selected torrents
+func (a *App) SetTorrentLabels(ids []int64, labels []string) error {
+	if a.service == nil {
+		return errors.New(ErrServiceNotInitialized)
+	}
+	if err := a.service.SetTorrentLabels(a.requestContext(), ids, labels); err != nil {
+		return errors.New(a.getLocalizedError(err))
+	}
+	return nil
+}
+
+// AddTorrentLabels adds labels to the selected torrents keeping existing ones
+func (a *App) AddTorrentLabels(ids []int64, labels []string) error {
+	if a.service == nil {
+		return errors.New(ErrServiceNotInitialized)
+	}
+	if err := a.service.AddTorrentLabels(a.requestContext(), ids, labels); err != nil {
+		return errors.New(a.getLocalizedError(err))
+	}
+	return nil
+}
+
+// RemoveTorrentLabels removes labels from the selected torrents
+func (a *App) RemoveTorrentLabels(ids []int64, labels []string) error {
+	if a.service == nil {
+		return errors.New(ErrServiceNotInitialized)
+	}
+	if err := a.service.RemoveTorrentLabels(a.requestContext(), ids, labels); err != nil {
+		return errors.New(a.getLocalizedError(err))
+	}
+	return nil
+}
+
+// GetLabelCounts returns all labels of the current server with torrent counts
+func (a *App) GetLabelCounts() ([]domain.LabelCount, error) {
+	if a.service == nil {
+		return nil, errors.New(ErrServiceNotInitialized)
+	}
+	return a.service.GetLabelCounts(a.requestContext())
+}
+
+// StartTorrentsNow starts the selected torrents bypassing the queue
+func (a *App) StartTorrentsNow(ids []int64) error {
+	if a.service == nil {
+		return errors.New(ErrServiceNotInitialized)
+	}
+	return a.service.StartTorrentsNow(a.requestContext(), ids)
+}
+
+// QueueMoveTop moves the selected torrents to the top of the queue
+func (a *App) QueueMoveTop(ids []int64) error {
+	if a.service == nil {
+		return errors.New(ErrServiceNotInitialized)
+	}
+	return a.service.QueueMoveTop(a.requestContext(), ids)
+}
+
+// QueueMoveUp moves the selected torrents one position up in the queue
+func (a *App) QueueMoveUp(ids []int64) error {
+	if a.service == nil {
+		return errors.New(ErrServiceNotInitialized)
+	}
+	return a.service.QueueMoveUp(a.requestContext(), ids)
+}
+
+// QueueMoveDown moves the selected torrents one position down in the queue
+func (a *App) QueueMoveDown(ids []int64) error {
+	if a.service == nil {
+		return errors.New(ErrServiceNotInitialized)
+	}
+	return a.service.QueueMoveDown(a.requestContext(), ids)
+}
+
+// QueueMoveBottom moves the selected torrents to the bottom of the queue
+func (a *App) QueueMoveBottom(ids []int64) error {
+	if a.service == nil {
+		return errors.New(ErrServiceNotInitialized)
+	}
+	return a.service.QueueMoveBottom(a.requestContext(), ids)
+}
+
+// GetQueueSettings returns download and seed queue settings of the session
+func (a *App) GetQueueSettings() (*domain.QueueSettings, error) {
+	if a.service == nil {
+		return nil, errors.New(ErrServiceNotInitialized)
+	}
+	return a.service.GetQueueSettings(a.requestContext())
+}
+
+// SetQueueSettings updates download and seed queue settings of the session
+func (a *App) SetQueueSettings(settings domain.QueueSettings) error {
+	if a.service == nil {
+		return errors.New(ErrServiceNotInitialized)
+	}
+	if err := a.service.SetQueueSettings(a.requestContext(), settings); err != nil {
+		return errors.New(a.getLocalizedError(err))
+	}
+	return nil
 }
 
 // TestConnection tests the connection to the Transmission server
@@ -199,26 +663,34 @@ func (a *App) TestConnection(configJson string) error {
 		return err
 	}
 
-	client, err := transmission.NewTransmissionClient(transmission.TransmissionConfig{
-		Host:     config.Host,
-		Port:     config.Port,
-		Username: config.Username,
-		Password: config.Password,
-	})
+	client, err := transmission.NewTransmissionClient(profileTransmissionConfig(config.ConnectionProfile()))
 	if err != nil {
-		return err
+		return errors.New(a.getLocalizedError(err))
 	}
 	// Try to get torrents as a connection test
-	_, err = client.GetAll()
+	_, err = client.GetAll(a.requestContext())
 	return err
 }
 
+// ValidateConnectionSettings проверяет схему, путь RPC и параметры TLS без подключения к серверу
+func (a *App) ValidateConnectionSettings(configJson string) error {
+	var config domain.Config
+	if err := json.Unmarshal([]byte(configJson), &config); err != nil {
+		return err
+	}
+
+	if err := transmission.ValidateConfig(profileTransmissionConfig(config.ConnectionProfile())); err != nil {
+		return errors.New(a.getLocalizedError(err))
+	}
+	return nil
+}
+
 // GetTorrentFiles returns the list of files in a torrent
 func (a *App) GetTorrentFiles(id int64) ([]domain.TorrentFile, error) {
 	if a.service == nil {
 		return nil, errors.New(ErrServiceNotInitialized)
 	}
-	return a.service.GetTorrentFiles(id)
+	return a.service.GetTorrentFiles(a.requestContext(), id)
 }
 
 // SetFilesWanted sets whether files should be downloaded
@@ -226,15 +698,71 @@ func (a *App) SetFilesWanted(id int64, fileIds []int, wanted bool) error {
 	if a.service == nil {
 		return errors.New(ErrServiceNotInitialized)
 	}
-	return a.service.SetFilesWanted(id, fileIds, wanted)
+	return a.service.SetFilesWanted(a.requestContext(), id, fileIds, wanted)
+}
+
+// RenameTorrentPath переименовывает файл или папку торрента и возвращает обновленный список файлов
+func (a *App) RenameTorrentPath(id int64, oldPath string, newName string) ([]domain.TorrentFile, error) {
+	if a.service == nil {
+		return nil, errors.New(ErrServiceNotInitialized)
+	}
+	files, err := a.service.RenameTorrentPath(a.requestContext(), id, oldPath, newName)
+	if err != nil {
+		return nil, errors.New(a.getLocalizedError(err))
+	}
+	return files, nil
+}
+
+// SetFilesPriority sets the download priority of the given files
+func (a *App) SetFilesPriority(id int64, fileIds []int, priority string) error {
+	if a.service == nil {
+		return errors.New(ErrServiceNotInitialized)
+	}
+	if err := a.service.SetFilesPriority(a.requestContext(), id, fileIds, domain.FilePriority(priority)); err != nil {
+		return errors.New(a.getLocalizedError(err))
+	}
+	return nil
+}
+
+// GetTorrentFileTree возвращает файлы торрента в виде дерева папок
+func (a *App) GetTorrentFileTree(id int64) (*domain.FileTreeNode, error) {
+	if a.service == nil {
+		return nil, errors.New(ErrServiceNotInitialized)
+	}
+	tree, err := a.service.GetTorrentFileTree(a.requestContext(), id)
+	if err != nil {
+		return nil, errors.New(a.getLocalizedError(err))
+	}
+	return tree, nil
+}
+
+// SetFolderWanted включает или отключает загрузку всех файлов папки
+func (a *App) SetFolderWanted(id int64, folderPath string, wanted bool) error {
+	if a.service == nil {
+		return errors.New(ErrServiceNotInitialized)
+	}
+	if err := a.service.SetFolderWanted(a.requestContext(), id, folderPath, wanted); err != nil {
+		return errors.New(a.getLocalizedError(err))
+	}
+	return nil
+}
+
+// SetFolderPriority задает приоритет загрузки всех файлов папки
+func (a *App) SetFolderPriority(id int64, folderPath string, priority string) error {
+	if a.service == nil {
+		return errors.New(ErrServiceNotInitialized)
+	}
+	if err := a.service.SetFolderPriority(a.requestContext(), id, folderPath, domain.FilePriority(priority)); err != nil {
+		return errors.New(a.getLocalizedError(err))
+	}
+	return nil
 }
 
-// SetTorrentSpeedLimit sets the speed limit for the given torrents
 func (a *App) SetTorrentSpeedLimit(ids []int64, isSlowMode bool) error {
 	if a.service == nil {
 		return errors.New(ErrServiceNotInitialized)
 	}
-	return a.service.SetTorrentSpeedLimit(ids, isSlowMode)
+	return a.service.SetTorrentSpeedLimit(a.requestContext(), ids, isSlowMode)
 }
 
 // GetDefaultDownloadDir возвращает каталог загрузки по умолчанию из Transmission
@@ -242,7 +770,7 @@ func (a *App) GetDefaultDownloadDir() (string, error) {
 	if a.service == nil {
 		return "", errors.New(ErrServiceNotInitialized)
 	}
-	return a.service.GetDefaultDownloadDir()
+	return a.service.GetDefaultDownloadDir(a.requestContext())
 }
 
 // SaveDownloadPath сохраняет путь в историю путей скачивания
@@ -258,7 +786,7 @@ func (a *App) GetDownloadPaths() ([]string, error) {
 	if a.service == nil {
 		return nil, errors.New(ErrServiceNotInitialized)
 	}
-	return a.service.GetDownloadPaths()
+	return a.service.GetDownloadPaths(a.requestContext())
 }
 
 // RemoveDownloadPath удаляет путь из истории путей скачивания
@@ -271,7 +799,8 @@ func (a *App) RemoveDownloadPath(path string) error {
 
 // getLocalizedError возвращает локализованное сообщение об ошибке
 func (a *App) getLocalizedError(err error) string {
-	if locErr, ok := err.(*transmission.LocalizedError); ok {
+	var locErr *transmission.LocalizedError
+	if errors.As(err, &locErr) {
 		// Получаем локализованное сообщение используя метод Translate
 		currentConfig, configErr := a.LoadConfig()
 		if configErr != nil {
@@ -287,7 +816,7 @@ func (a *App) ValidateDownloadPath(path string) error {
 	if a.service == nil {
 		return errors.New(ErrServiceNotInitialized)
 	}
-	if err := a.service.ValidateDownloadPath(path); err != nil {
+	if err := a.service.ValidateDownloadPath(a.requestContext(), path); err != nil {
 		// Возвращаем локализованное сообщение об ошибке
 		return errors.New(a.getLocalizedError(err)) // заменено
 	}
@@ -316,10 +845,251 @@ func (a *App) ReadFile(filePath string) (string, error) {
 	return base64.StdEncoding.EncodeToString(data), nil
 }
 
+// PreviewTorrentFile разбирает .torrent файл в base64 без отправки демону,
+// чтобы показать его содержимое и выбрать файлы перед добавлением
+func (a *App) PreviewTorrentFile(base64Content string) (*domain.TorrentPreview, error) {
+	preview, err := metainfo.ParseBase64(base64Content)
+	if err != nil {
+		return nil, errors.New(a.localizationService.Translate("errors.invalidTorrentFile", a.currentLanguage(), err.Error()))
+	}
+	return preview, nil
+}
+
+// PreviewMagnet разбирает magnet-ссылку и возвращает имя, трекеры и info-hash
+func (a *App) PreviewMagnet(link string) (*domain.MagnetPreview, error) {
+	preview, err := metainfo.ParseMagnet(link)
+	if err != nil {
+		return nil, errors.New(a.localizationService.Translate("errors.invalidMagnet", a.currentLanguage()))
+	}
+	return preview, nil
+}
+
 // VerifyTorrent запускает проверку целостности торрента
 func (a *App) VerifyTorrent(id int64) error {
 	if a.service == nil {
 		return errors.New(ErrServiceNotInitialized)
 	}
-	return a.service.VerifyTorrent(id)
+	return a.service.VerifyTorrent(a.requestContext(), id)
+}
+
+// MoveTorrents переносит данные торрентов в новую директорию или указывает,
+// что данные уже находятся там (move = false)
+func (a *App) MoveTorrents(ids []int64, newLocation string, move bool) error {
+	if a.service == nil {
+		return errors.New(ErrServiceNotInitialized)
+	}
+	if err := a.service.MoveTorrents(a.requestContext(), ids, newLocation, move); err != nil {
+		return errors.New(a.getLocalizedError(err))
+	}
+	a.poller.Trigger()
+	return nil
+}
+
+// GetTorrentTrackers возвращает трекеры торрента со статистикой анонсов
+func (a *App) GetTorrentTrackers(id int64) ([]domain.TorrentTracker, error) {
+	if a.service == nil {
+		return nil, errors.New(ErrServiceNotInitialized)
+	}
+	return a.service.GetTorrentTrackers(a.requestContext(), id)
+}
+
+// AddTrackers добавляет трекеры к торренту
+func (a *App) AddTrackers(id int64, announceURLs []string) error {
+	if a.service == nil {
+		return errors.New(ErrServiceNotInitialized)
+	}
+	if err := a.service.AddTrackers(a.requestContext(), id, announceURLs); err != nil {
+		return errors.New(a.getLocalizedError(err))
+	}
+	return nil
+}
+
+// ReplaceTracker заменяет адрес анонса трекера
+func (a *App) ReplaceTracker(id int64, trackerID int64, announceURL string) error {
+	if a.service == nil {
+		return errors.New(ErrServiceNotInitialized)
+	}
+	if err := a.service.ReplaceTracker(a.requestContext(), id, trackerID, announceURL); err != nil {
+		return errors.New(a.getLocalizedError(err))
+	}
+	return nil
+}
+
+// RemoveTrackers удаляет трекеры торрента
+func (a *App) RemoveTrackers(id int64, trackerIDs []int64) error {
+	if a.service == nil {
+		return errors.New(ErrServiceNotInitialized)
+	}
+	if err := a.service.RemoveTrackers(a.requestContext(), id, trackerIDs); err != nil {
+		return errors.New(a.getLocalizedError(err))
+	}
+	return nil
+}
+
+// GetTorrentPeers возвращает подключенных пиров торрента
+func (a *App) GetTorrentPeers(id int64) (*domain.TorrentPeers, error) {
+	if a.service == nil {
+		return nil, errors.New(ErrServiceNotInitialized)
+	}
+	return a.service.GetTorrentPeers(a.requestContext(), id)
+}
+
+// PreviewTrackerRewrite показывает, какие адреса трекеров будут заменены
+func (a *App) PreviewTrackerRewrite(mode string, pattern string, replacement string) (*domain.TrackerRewriteReport, error) {
+	if a.service == nil {
+		return nil, errors.New(ErrServiceNotInitialized)
+	}
+	report, err := a.service.PreviewTrackerRewrite(a.requestContext(), domain.TrackerMatchMode(mode), pattern, replacement)
+	if err != nil {
+		return nil, err
+	}
+	a.translateTrackerRewriteReport(report)
+	return report, nil
+}
+
+// ApplyTrackerRewrite применяет замены, полученные из PreviewTrackerRewrite
+func (a *App) ApplyTrackerRewrite(changes []domain.TrackerRewriteChange) (*domain.TrackerRewriteReport, error) {
+	if a.service == nil {
+		return nil, errors.New(ErrServiceNotInitialized)
+	}
+	report, err := a.service.ApplyTrackerRewrite(a.requestContext(), changes)
+	if err != nil {
+		return nil, err
+	}
+	a.translateTrackerRewriteReport(report)
+	return report, nil
+}
+
+// translateTrackerRewriteReport переводит ошибки отчета о замене трекеров.
+// Translate возвращает строку без изменений, если это не ключ локализации.
+func (a *App) translateTrackerRewriteReport(report *domain.TrackerRewriteReport) {
+	language := a.currentLanguage()
+	for i := range report.Changes {
+		if report.Changes[i].Error != "" {
+			report.Changes[i].Error = a.localizationService.Translate(report.Changes[i].Error, language)
+		}
+	}
+	for i := range report.Results {
+		if report.Results[i].Error != "" {
+			report.Results[i].Error = a.localizationService.Translate(report.Results[i].Error, language)
+		}
+	}
+}
+
+// GetTorrentDetails возвращает подробные сведения о торренте
+func (a *App) GetTorrentDetails(id int64) (*domain.TorrentDetails, error) {
+	if a.service == nil {
+		return nil, errors.New(ErrServiceNotInitialized)
+	}
+	return a.service.GetTorrentDetails(a.requestContext(), id)
+}
+
+// SetSeedingPolicy задает торрентам собственные ограничения раздачи
+func (a *App) SetSeedingPolicy(ids []int64, policy domain.SeedingPolicy) error {
+	if a.service == nil {
+		return errors.New(ErrServiceNotInitialized)
+	}
+	if err := a.service.SetSeedingPolicy(a.requestContext(), ids, policy); err != nil {
+		return errors.New(a.getLocalizedError(err))
+	}
+	return nil
+}
+
+// GetSpeedSettings возвращает ограничения скорости сессии и расписание альтернативной скорости
+func (a *App) GetSpeedSettings() (*domain.SpeedSettings, error) {
+	if a.service == nil {
+		return nil, errors.New(ErrServiceNotInitialized)
+	}
+	return a.service.GetSpeedSettings(a.requestContext())
+}
+
+// SetSpeedSettings сохраняет ограничения скорости сессии и расписание альтернативной скорости
+func (a *App) SetSpeedSettings(settings domain.SpeedSettings) error {
+	if a.service == nil {
+		return errors.New(ErrServiceNotInitialized)
+	}
+	if err := a.service.SetSpeedSettings(a.requestContext(), settings); err != nil {
+		return errors.New(a.getLocalizedError(err))
+	}
+	a.poller.Trigger()
+	return nil
+}
+
+// SetAltSpeedEnabled включает или выключает альтернативные ограничения скорости
+func (a *App) SetAltSpeedEnabled(enabled bool) error {
+	if a.service == nil {
+		return errors.New(ErrServiceNotInitialized)
+	}
+	if err := a.service.SetAltSpeedEnabled(a.requestContext(), enabled); err != nil {
+		return err
+	}
+	a.poller.Trigger()
+	return nil
+}
+
+// GetSessionSettings возвращает настройки демона Transmission
+func (a *App) GetSessionSettings() (*domain.SessionSettings, error) {
+	if a.service == nil {
+		return nil, errors.New(ErrServiceNotInitialized)
+	}
+	return a.service.GetSessionSettings(a.requestContext())
+}
+
+// UpdateSessionSettings сохраняет настройки демона Transmission, отправляя только изменения
+func (a *App) UpdateSessionSettings(settings domain.SessionSettings) error {
+	if a.service == nil {
+		return errors.New(ErrServiceNotInitialized)
+	}
+	if err := a.service.UpdateSessionSettings(a.requestContext(), settings); err != nil {
+		return errors.New(a.getLocalizedError(err))
+	}
+	return nil
+}
+
+// currentLanguage возвращает язык интерфейса из сохраненной конфигурации
+func (a *App) currentLanguage() string {
+	config, err := a.LoadConfig()
+	if err != nil || config == nil || config.Language == "" {
+		return a.localizationService.GetSystemLocale()
+	}
+	return config.Language
+}
+
+// TestPort проверяет, доступен ли порт для пиров из интернета
+func (a *App) TestPort() (*domain.PortTestResult, error) {
+	if a.service == nil {
+		return nil, errors.New(ErrServiceNotInitialized)
+	}
+
+	language := a.currentLanguage()
+	open, err := a.service.TestPort(a.requestContext())
+	if err != nil {
+		return nil, errors.New(a.localizationService.Translate("diagnostics.portTestFailed", language, err.Error()))
+	}
+
+	key := "diagnostics.portClosed"
+	if open {
+		key = "diagnostics.portOpen"
+	}
+	return &domain.PortTestResult{
+		Open:    open,
+		Message: a.localizationService.Translate(key, language),
+	}, nil
+}
+
+// UpdateBlocklist загружает список блокировки по адресу из настроек демона
+func (a *App) UpdateBlocklist() (*domain.BlocklistUpdateResult, error) {
+	if a.service == nil {
+		return nil, errors.New(ErrServiceNotInitialized)
+	}
+
+	language := a.currentLanguage()
+	size, err := a.service.UpdateBlocklist(a.requestContext())
+	if err != nil {
+		return nil, errors.New(a.localizationService.Translate("diagnostics.blocklistUpdateFailed", language, err.Error()))
+	}
+	return &domain.BlocklistUpdateResult{
+		Size:    size,
+		Message: a.localizationService.Translate("diagnostics.blocklistUpdated", language, size),
+	}, nil
 }
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"transmission-client-go/internal/domain"
+)
+
+func savedTestConfig() *domain.Config {
+	config := &domain.Config{
+		Language:      "en",
+		Theme:         "dark",
+		ActiveProfile: "home",
+		Profiles: []domain.ServerProfile{
+			{
+				ID: "home", Name: "Home", Host: "nas.local", Port: 9091, Username: "admin", Password: "secret",
+				Scheme: "https", RPCPath: "/custom/rpc",
+				CACertPath: "/certs/ca.pem", ClientCertPath: "/certs/client.pem", ClientKeyPath: "/certs/client.key",
+				AllowInsecure: true, ConnectTimeout: 7, RequestTimeout: 45,
+				MaxUploadRatio: 2, SlowSpeedLimit: 50, SlowSpeedUnit: "KiB/s",
+				DownloadPaths: []string{"/data/movies"}, DefaultDownloadPath: "/data",
+			},
+			{ID: "office", Name: "Office", Host: "office.local", Port: 9091, RPCPath: "/transmission/rpc"},
+		},
+		PolicyRules: []domain.PolicyRule{{ID: "ratio"}},
+	}
+	config.ApplyProfile(&config.Profiles[0])
+	return config
+}
+
+func TestMergeConfigSettingsSaveKeepsProfile(t *testing.T) {
+	saved := savedTestConfig()
+	want := saved.Profiles[0]
+	want.Host, want.Port, want.Password = "nas.lan", 9092, "changed"
+	want.MaxUploadRatio, want.SlowSpeedLimit, want.SlowSpeedUnit = 3, 2, "MiB/s"
+
+	// Форма настроек присылает только параметры подключения, ограничения, язык и тему
+	payload, _ := json.Marshal(map[string]any{
+		"host": "nas.lan", "port": 9092, "username": "admin", "password": "changed",
+		"maxUploadRatio": 3, "slowSpeedLimit": 2, "slowSpeedUnit": "MiB/s",
+		"language": "ru", "theme": "dark",
+	})
+
+	config, err := mergeConfig(saved, string(payload))
+	if err != nil {
+		t.Fatalf("mergeConfig() error = %v", err)
+	}
+	config.SyncActiveProfile()
+
+	if config.ActiveProfile != "home" || config.Language != "ru" {
+		t.Errorf("ActiveProfile = %q, Language = %q", config.ActiveProfile, config.Language)
+	}
+	if len(config.Profiles) != 2 || !reflect.DeepEqual(config.Profiles[0], want) {
+		t.Errorf("active profile = %+v, want %+v", config.Profiles[0], want)
+	}
+	if !reflect.DeepEqual(config.Profiles[1], savedTestConfig().Profiles[1]) {
+		t.Errorf("other profile changed: %+v", config.Profiles[1])
+	}
+	if len(config.PolicyRules) != 1 {
+		t.Errorf("PolicyRules = %+v, want the saved rules", config.PolicyRules)
+	}
+}
+
+func TestMergeConfigLanguageSwitchKeepsProfile(t *testing.T) {
+	saved := savedTestConfig()
+
+	// Смена языка присылает всю загруженную конфигурацию
+	current := savedTestConfig()
+	current.Language = "ru"
+	payload, _ := json.Marshal(current)
+
+	config, err := mergeConfig(saved, string(payload))
+	if err != nil {
+		t.Fatalf("mergeConfig() error = %v", err)
+	}
+	config.SyncActiveProfile()
+
+	if config.Language != "ru" {
+		t.Errorf("Language = %q, want ru", config.Language)
+	}
+	if !reflect.DeepEqual(config.Profiles, savedTestConfig().Profiles) {
+		t.Errorf("Profiles = %+v, want unchanged", config.Profiles)
+	}
+}
+
+func TestSyncActiveProfileMissingProfile(t *testing.T) {
+	config := savedTestConfig()
+	config.ActiveProfile = "deleted"
+	config.Host = "other.local"
+
+	config.SyncActiveProfile()
+
+	if len(config.Profiles) != 3 || config.Profiles[2].ID != "deleted" || config.Profiles[2].Host != "other.local" {
+		t.Fatalf("Profiles = %+v, want a new profile for the missing ID", config.Profiles)
+	}
+	if !reflect.DeepEqual(config.Profiles[:2], savedTestConfig().Profiles) {
+		t.Errorf("existing profiles changed: %+v", config.Profiles[:2])
+	}
+}
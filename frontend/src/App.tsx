@@ -47,6 +47,7 @@ function App() {
 
   const [searchTerm, setSearchTerm] = useState("");
   const [statusFilter, setStatusFilter] = useState<string | null>(null);
+  const [labelFilter, setLabelFilter] = useState<string | null>(null);
   const [showSettings, setShowSettings] = useState(false);
   const [showAddTorrent, setShowAddTorrent] = useState(false);
   const [torrentFilePath, setTorrentFilePath] = useState<string | null>(null);
@@ -56,6 +57,7 @@ function App() {
     torrents,
     selectedTorrents,
     error,
+    notice,
     hasSelectedTorrents,
     sessionStats,
     isLoading,
@@ -88,7 +90,31 @@ function App() {
     config || undefined
   );
 
-  // Фильтрация торрентов по поисковому запросу и статусу
+  // Метки всех торрентов с количеством торрентов для фильтра. Как и на сервере
+  // (GetLabelCounts), метки, отличающиеся только регистром, считаются одной меткой
+  const labelNames = new Map<string, string>();
+  const labelCounts = new Map<string, number>();
+  torrents.forEach((torrent) => {
+    const seen = new Set<string>();
+    (torrent.Labels ?? []).forEach((label) => {
+      const key = label.toLowerCase();
+      if (seen.has(key)) return;
+      seen.add(key);
+
+      if (!labelNames.has(key)) labelNames.set(key, label);
+      const name = labelNames.get(key)!;
+      labelCounts.set(name, (labelCounts.get(name) ?? 0) + 1);
+    });
+  });
+
+  // Сбрасываем фильтр, если метка больше не назначена ни одному торренту
+  useEffect(() => {
+    if (labelFilter && !labelNames.has(labelFilter.toLowerCase())) {
+      setLabelFilter(null);
+    }
+  }, [torrents, labelFilter]);
+
+  // Фильтрация торрентов по поисковому запросу, статусу и метке
   const filteredTorrents = torrents.filter((torrent) => {
     const matchesSearch = torrent.Name.toLowerCase().includes(
       searchTerm.toLowerCase()
@@ -100,7 +126,12 @@ function App() {
         : statusFilter === "queued"
         ? ["queued", "queuedCheck", "queuedDownload"].includes(torrent.Status)
         : torrent.Status === statusFilter);
-    return matchesSearch && matchesStatus;
+    const matchesLabel =
+      !labelFilter ||
+      (torrent.Labels ?? []).some(
+        (label) => label.toLowerCase() === labelFilter.toLowerCase()
+      );
+    return matchesSearch && matchesStatus && matchesLabel;
   });
 
   // Проверяем, есть ли замедленные торренты среди выбранных
@@ -146,9 +177,13 @@ function App() {
           onSelectAll={handleSelectAllAdapter}
           statusFilter={statusFilter}
           onStatusFilterChange={setStatusFilter}
+          labelCounts={labelCounts}
+          labelFilter={labelFilter}
+          onLabelFilterChange={setLabelFilter}
           torrents={torrents}
           onSetSpeedLimit={handleSetSpeedLimit}
           isSlowModeEnabled={selectedHaveSlowMode}
+          notice={notice ?? undefined}
         />
         {isReconnecting && (
           <div className={styles.reconnectingOverlay}>
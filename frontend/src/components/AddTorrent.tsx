@@ -9,6 +9,7 @@ import {
   TextField,
   Select,
   IconButton,
+  Checkbox,
 } from "@radix-ui/themes";
 import { useLocalization } from "../contexts/LocalizationContext";
 import { LoadingSpinner } from "./LoadingSpinner";
@@ -19,11 +20,61 @@ import {
   ValidateDownloadPath,
   RemoveDownloadPath,
   ReadFile,
+  PreviewTorrentFile,
+  PreviewMagnet,
+  GetSessionSettings,
 } from "../../wailsjs/go/main/App";
 
+// Параметры добавления торрента (domain.AddTorrentOptions)
+export interface AddTorrentOptions {
+  DownloadDir: string;
+  Paused?: boolean; // Не задано — по настройке демона
+  BandwidthPriority: string;
+  PeerLimit: number;
+  Labels: string[];
+  FilesWanted: number[];
+  FilesUnwanted: number[];
+  PriorityHigh: number[];
+  PriorityLow: number[];
+  Cookies: string;
+  MergeTrackers: boolean;
+}
+
+// Сведения о .torrent файле (domain.TorrentPreview)
+interface TorrentPreview {
+  Name: string;
+  TotalSize: number;
+  PieceSize: number;
+  Files: { Index: number; Path: string; Size: number }[] | null;
+}
+
+// Сведения из magnet-ссылки (domain.MagnetPreview)
+interface MagnetPreview {
+  Name: string;
+  InfoHash: string;
+  InfoHashV2: string;
+  Trackers: string[] | null;
+  Size: number;
+}
+
+// Форматирует размер в байтах для списка файлов
+const formatSize = (bytes: number): string => {
+  const units = ["B", "KiB", "MiB", "GiB", "TiB"];
+  let value = bytes;
+  let unit = 0;
+  while (value >= 1024 && unit < units.length - 1) {
+    value /= 1024;
+    unit++;
+  }
+  return `${value.toFixed(unit === 0 ? 0 : 1)} ${units[unit]}`;
+};
+
 export interface AddTorrentProps {
-  onAdd: (url: string, downloadDir?: string) => Promise<boolean>;
-  onAddFile: (base64Content: string, downloadDir?: string) => Promise<boolean>;
+  onAdd: (url: string, options: AddTorrentOptions) => Promise<boolean>;
+  onAddFile: (
+    base64Content: string,
+    options: AddTorrentOptions
+  ) => Promise<boolean>;
   onClose: () => void;
   torrentFile?: string; // добавлено для передачи пути торрент файла
 }
@@ -76,6 +127,76 @@ export const AddTorrent: React.FC<AddTorrentProps> = ({
   const [showCustomPath, setShowCustomPath] = useState<boolean>(false);
   const [pathError, setPathError] = useState<string>("");
   const [defaultPath, setDefaultPath] = useState<string>("");
+  // Выбор пользователя; пока флажок не трогали, действует настройка демона
+  const [startPaused, setStartPaused] = useState<boolean | undefined>(
+    undefined
+  );
+  const [daemonStartsPaused, setDaemonStartsPaused] = useState<boolean>(false);
+  const [bandwidthPriority, setBandwidthPriority] = useState<string>("normal");
+  const [peerLimit, setPeerLimit] = useState<string>("");
+  const [labels, setLabels] = useState<string>("");
+  const [mergeTrackers, setMergeTrackers] = useState<boolean>(false);
+  const [preview, setPreview] = useState<TorrentPreview | null>(null);
+  const [previewError, setPreviewError] = useState<string>("");
+  const [unwantedFiles, setUnwantedFiles] = useState<Set<number>>(new Set());
+
+  // Разбираем выбранный .torrent файл, чтобы показать список файлов
+  useEffect(() => {
+    setPreview(null);
+    setPreviewError("");
+    setUnwantedFiles(new Set());
+    if (!selectedFileData) {
+      return;
+    }
+
+    PreviewTorrentFile(selectedFileData)
+      .then((result: TorrentPreview) => setPreview(result))
+      .catch((error: Error) => setPreviewError(String(error)));
+  }, [selectedFileData]);
+
+  const [magnetPreview, setMagnetPreview] = useState<MagnetPreview | null>(
+    null
+  );
+  const [magnetError, setMagnetError] = useState<string>("");
+
+  // Проверяем magnet-ссылку до отправки демону
+  useEffect(() => {
+    setMagnetPreview(null);
+    setMagnetError("");
+    const link = url.trim();
+    if (!link.toLowerCase().startsWith("magnet:")) {
+      return;
+    }
+
+    let cancelled = false;
+    PreviewMagnet(link)
+      .then((result: MagnetPreview) => !cancelled && setMagnetPreview(result))
+      .catch((error: Error) => !cancelled && setMagnetError(String(error)));
+    return () => {
+      cancelled = true;
+    };
+  }, [url]);
+
+  const toggleFileWanted = (index: number) => {
+    setUnwantedFiles((prev) => {
+      const next = new Set(prev);
+      if (next.has(index)) {
+        next.delete(index);
+      } else {
+        next.add(index);
+      }
+      return next;
+    });
+  };
+
+  // Показываем в флажке, добавляет ли демон торренты остановленными
+  useEffect(() => {
+    GetSessionSettings()
+      .then((settings) => setDaemonStartsPaused(!settings.StartAddedTorrents))
+      .catch((error: Error) =>
+        console.error("Failed to load session settings:", error)
+      );
+  }, []);
 
   // Получаем список путей при инициализации
   useEffect(() => {
@@ -137,11 +258,28 @@ export const AddTorrent: React.FC<AddTorrentProps> = ({
       return;
     }
 
+    const options: AddTorrentOptions = {
+      DownloadDir: pathToUse,
+      Paused: startPaused,
+      BandwidthPriority: bandwidthPriority,
+      PeerLimit: Math.max(0, parseInt(peerLimit, 10) || 0),
+      Labels: labels
+        .split(",")
+        .map((label) => label.trim())
+        .filter(Boolean),
+      FilesWanted: [],
+      FilesUnwanted: activeTab === "file" ? Array.from(unwantedFiles) : [],
+      PriorityHigh: [],
+      PriorityLow: [],
+      Cookies: "",
+      MergeTrackers: mergeTrackers,
+    };
+
     if (activeTab === "url" && url.trim()) {
-      onAdd(url.trim(), pathToUse);
+      onAdd(url.trim(), options);
       onClose();
     } else if (activeTab === "file" && selectedFileData) {
-      onAddFile(selectedFileData, pathToUse);
+      onAddFile(selectedFileData, options);
       onClose();
     }
   };
@@ -269,6 +407,27 @@ export const AddTorrent: React.FC<AddTorrentProps> = ({
                       value={url}
                       onChange={(e) => setUrl(e.target.value)}
                     />
+                    {magnetError && (
+                      <Text as="div" color="red" size="2" mt="2">
+                        {magnetError}
+                      </Text>
+                    )}
+                    {magnetPreview && (
+                      <Box mt="2">
+                        <Text as="div" size="2" weight="bold">
+                          {magnetPreview.Name ||
+                            magnetPreview.InfoHash ||
+                            magnetPreview.InfoHashV2}
+                          {magnetPreview.Size > 0 &&
+                            ` (${formatSize(magnetPreview.Size)})`}
+                        </Text>
+                        {(magnetPreview.Trackers ?? []).map((tracker) => (
+                          <Text as="div" size="1" color="gray" key={tracker}>
+                            {tracker}
+                          </Text>
+                        ))}
+                      </Box>
+                    )}
                   </Tabs.Content>
                   <Tabs.Content value="file">
                     <Flex direction="column" gap="2">
@@ -306,6 +465,36 @@ export const AddTorrent: React.FC<AddTorrentProps> = ({
                           <Text size="2">{selectedFileName}</Text>
                         </Box>
                       )}
+                      {previewError && (
+                        <Text color="red" size="2">
+                          {previewError}
+                        </Text>
+                      )}
+                      {preview && (
+                        <Box>
+                          <Text as="div" size="2" weight="bold" mb="1">
+                            {preview.Name} ({formatSize(preview.TotalSize)})
+                          </Text>
+                          <Box style={{ maxHeight: 160, overflowY: "auto" }}>
+                            <Flex direction="column" gap="1">
+                              {(preview.Files ?? []).map((file) => (
+                                <Text as="label" size="1" key={file.Index}>
+                                  <Flex gap="2" align="center">
+                                    <Checkbox
+                                      checked={!unwantedFiles.has(file.Index)}
+                                      onCheckedChange={() =>
+                                        toggleFileWanted(file.Index)
+                                      }
+                                    />
+                                    <span style={{ flex: 1 }}>{file.Path}</span>
+                                    <span>{formatSize(file.Size)}</span>
+                                  </Flex>
+                                </Text>
+                              ))}
+                            </Flex>
+                          </Box>
+                        </Box>
+                      )}
                       <input
                         ref={fileInputRef}
                         type="file"
@@ -379,6 +568,81 @@ export const AddTorrent: React.FC<AddTorrentProps> = ({
                     )}
                   </Flex>
                 </Box>
+
+                {/* Параметры добавления */}
+                <Box mt="4">
+                  <Text as="div" size="2" mb="2" weight="bold">
+                    {t("add.options")}
+                  </Text>
+
+                  <Flex direction="column" gap="2">
+                    <Text as="label" size="2">
+                      <Flex gap="2" align="center">
+                        <Checkbox
+                          checked={startPaused ?? daemonStartsPaused}
+                          onCheckedChange={(checked) =>
+                            setStartPaused(checked === true)
+                          }
+                        />
+                        {t("add.startPaused")}
+                      </Flex>
+                    </Text>
+
+                    <Text as="label" size="2">
+                      <Flex gap="2" align="center">
+                        <Checkbox
+                          checked={mergeTrackers}
+                          onCheckedChange={(checked) =>
+                            setMergeTrackers(checked === true)
+                          }
+                        />
+                        {t("add.mergeTrackers")}
+                      </Flex>
+                    </Text>
+
+                    <Flex gap="2" align="center" justify="between">
+                      <Text size="2">{t("add.priority")}</Text>
+                      <Select.Root
+                        size="1"
+                        value={bandwidthPriority}
+                        onValueChange={setBandwidthPriority}
+                      >
+                        <Select.Trigger />
+                        <Select.Content>
+                          <Select.Item value="high">
+                            {t("add.priorityHigh")}
+                          </Select.Item>
+                          <Select.Item value="normal">
+                            {t("add.priorityNormal")}
+                          </Select.Item>
+                          <Select.Item value="low">
+                            {t("add.priorityLow")}
+                          </Select.Item>
+                        </Select.Content>
+                      </Select.Root>
+                    </Flex>
+
+                    <Flex gap="2" align="center" justify="between">
+                      <Text size="2">{t("add.peerLimit")}</Text>
+                      <TextField.Root
+                        size="1"
+                        type="number"
+                        min="0"
+                        placeholder={t("add.peerLimitDefault")}
+                        value={peerLimit}
+                        onChange={(e) => setPeerLimit(e.target.value)}
+                        style={{ width: 120 }}
+                      />
+                    </Flex>
+
+                    <TextField.Root
+                      size="1"
+                      placeholder={t("add.labels")}
+                      value={labels}
+                      onChange={(e) => setLabels(e.target.value)}
+                    />
+                  </Flex>
+                </Box>
               </Tabs.Root>
               <Flex justify="end" gap="3" mt="4">
                 <Button size="1" variant="soft" onClick={onClose}>
@@ -388,8 +652,13 @@ export const AddTorrent: React.FC<AddTorrentProps> = ({
                   size="1"
                   type="submit"
                   disabled={
-                    (activeTab === "url" && !url.trim()) ||
-                    (activeTab === "file" && !selectedFileData)
+                    (activeTab === "url" && (!url.trim() || !!magnetError)) ||
+                    (activeTab === "file" &&
+                      (!selectedFileData ||
+                        !!previewError ||
+                        (preview !== null &&
+                          unwantedFiles.size ===
+                            (preview.Files ?? []).length)))
                   }
                 >
                   {t("add.add")}
@@ -40,8 +40,12 @@ interface HeaderProps {
   selectedTorrents: Set<number>;
   onSelectAll: () => void;
   error?: string; // Сделал свойство error необязательным
+  notice?: string; // Информационное сообщение, например о торренте-дубликате
   statusFilter: string | null;
   onStatusFilterChange: (status: string | null) => void;
+  labelCounts: Map<string, number>;
+  labelFilter: string | null;
+  onLabelFilterChange: (label: string | null) => void;
   torrents: Array<any>;
   onSetSpeedLimit: (isSlowMode: boolean) => void;
   isSlowModeEnabled?: boolean;
@@ -63,8 +67,12 @@ export const Header: React.FC<HeaderProps> = ({
   selectedTorrents,
   onSelectAll,
   error,
+  notice,
   statusFilter,
   onStatusFilterChange,
+  labelCounts,
+  labelFilter,
+  onLabelFilterChange,
   torrents,
   onSetSpeedLimit,
   isSlowModeEnabled = false,
@@ -213,6 +221,9 @@ export const Header: React.FC<HeaderProps> = ({
         <StatusFilter
           selectedStatus={statusFilter}
           onStatusChange={onStatusFilterChange}
+          labelCounts={labelCounts}
+          selectedLabel={labelFilter}
+          onLabelChange={onLabelFilterChange}
           hasNoTorrents={torrents.length === 0}
         />
       </Box>
@@ -225,6 +236,14 @@ export const Header: React.FC<HeaderProps> = ({
         </Box>
       )}
 
+      {notice && (
+        <Box className={styles.noticeMessage}>
+          <Text color="blue" size="1">
+            {notice}
+          </Text>
+        </Box>
+      )}
+
       <DeleteDialog
         mode="bulk"
         count={selectedTorrents.size}
@@ -5,12 +5,18 @@ import { Button, Flex } from "@radix-ui/themes";
 interface StatusFilterProps {
   selectedStatus: string | null;
   onStatusChange: (status: string | null) => void;
+  labelCounts: Map<string, number>;
+  selectedLabel: string | null;
+  onLabelChange: (label: string | null) => void;
   hasNoTorrents: boolean;
 }
 
 export const StatusFilter: React.FC<StatusFilterProps> = ({
   selectedStatus,
   onStatusChange,
+  labelCounts,
+  selectedLabel,
+  onLabelChange,
   hasNoTorrents,
 }) => {
   const { t } = useLocalization();
@@ -34,6 +40,14 @@ export const StatusFilter: React.FC<StatusFilterProps> = ({
     onStatusChange(selectedStatus === id ? null : id);
   };
 
+  const handleLabelClick = (label: string) => {
+    onLabelChange(selectedLabel === label ? null : label);
+  };
+
+  const labels = Array.from(labelCounts.entries()).sort(([a], [b]) =>
+    a.localeCompare(b)
+  );
+
   return (
     <Flex gap="3" align="center" style={{ margin: "0 8px" }}>
       {statuses.map(({ id, label, color }) => (
@@ -49,6 +63,18 @@ export const StatusFilter: React.FC<StatusFilterProps> = ({
           {t(`filters.${label}`)}
         </Button>
       ))}
+      {labels.map(([label, count]) => (
+        <Button
+          key={`label-${label}`}
+          size="1"
+          color="cyan"
+          variant={selectedLabel === label ? "solid" : "outline"}
+          onClick={() => handleLabelClick(label)}
+          style={{ minWidth: "auto", padding: "0 12px" }}
+        >
+          {`${label} (${count})`}
+        </Button>
+      ))}
     </Flex>
   );
 };
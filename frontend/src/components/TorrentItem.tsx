@@ -32,7 +32,6 @@ interface TorrentItemProps {
   progress: number;
   sizeFormatted: string;
   uploadRatio: number;
-  seedsConnected: number;
   seedsTotal: number;
   peersConnected: number;
   peersTotal: number;
@@ -72,7 +71,6 @@ export const TorrentItem: React.FC<TorrentItemProps> = ({
   progress,
   sizeFormatted,
   uploadRatio,
-  seedsConnected,
   seedsTotal,
   peersConnected,
   peersTotal,
@@ -150,6 +148,7 @@ export const TorrentItem: React.FC<TorrentItemProps> = ({
       queued: { color: "purple" },
       queuedCheck: { color: "purple" },
       queuedDownload: { color: "purple" },
+      moving: { color: "amber" },
       stopped: { color: "gray" },
     };
 
@@ -299,7 +298,8 @@ export const TorrentItem: React.FC<TorrentItemProps> = ({
         {renderStatItem("size", sizeFormatted)}
         {renderStatItem(
           "seeds",
-          `${normalizeValue(seedsConnected)}/${normalizeValue(seedsTotal)}`
+          // Число подключенных сидов есть только в списке пиров торрента
+          String(normalizeValue(seedsTotal))
         )}
         {renderStatItem(
           "peers",
@@ -12,7 +12,7 @@ export interface TorrentData {
   Size: number;
   SizeFormatted: string;
   UploadRatio: number;
-  SeedsConnected: number;
+  PeersSendingToUs: number;
   SeedsTotal: number;
   PeersConnected: number;
   PeersTotal: number;
@@ -23,6 +23,7 @@ export interface TorrentData {
   DownloadSpeedFormatted: string;
   UploadSpeedFormatted: string;
   IsSlowMode: boolean;
+  Labels: string[] | null;
 }
 
 interface TorrentListProps {
@@ -82,7 +83,6 @@ export const TorrentList: React.FC<TorrentListProps> = ({
           progress={torrent.Progress}
           sizeFormatted={torrent.SizeFormatted}
           uploadRatio={torrent.UploadRatio}
-          seedsConnected={torrent.SeedsConnected}
           seedsTotal={torrent.SeedsTotal}
           peersConnected={torrent.PeersConnected}
           peersTotal={torrent.PeersTotal}
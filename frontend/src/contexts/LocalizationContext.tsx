@@ -111,6 +111,7 @@ export const LocalizationProvider: React.FC<LocalizationProviderProps> = ({
         "torrent.status.completed",
         "torrent.status.queuedCheck",
         "torrent.status.queuedDownload",
+        "torrent.status.moving",
         "torrent.start",
         "torrent.stop",
         "torrent.remove",
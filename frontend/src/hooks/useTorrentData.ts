@@ -1,5 +1,6 @@
 import { useState, useEffect, useCallback } from "react";
 import { TorrentData } from "../components/TorrentList";
+import { AddTorrentOptions } from "../components/AddTorrent";
 import { useLocalization } from "../contexts/LocalizationContext";
 import { ConnectionConfig, UIConfig, ConfigData } from "../App";
 import {
@@ -14,7 +15,12 @@ import {
   GetSessionStats,
   SetTorrentSpeedLimit,
   VerifyTorrent,
+  SetWindowVisible,
 } from "../../wailsjs/go/main/App";
+import { EventsOn } from "../../wailsjs/runtime/runtime";
+
+// Сколько показывается информационное сообщение
+const NOTICE_TIMEOUT_MS = 5000;
 
 // Интерфейс для статистики сессии
 interface SessionStatsData {
@@ -24,19 +30,12 @@ interface SessionStatsData {
   TransmissionVersion: string;
 }
 
-// Функция для создания таймаута
-const withTimeout = <T>(
-  promise: Promise<T>,
-  timeout: number,
-  t: (key: string) => string
-): Promise<T> => {
-  return Promise.race([
-    promise,
-    new Promise<T>((_, reject) =>
-      setTimeout(() => reject(new Error(t("errors.timeout"))), timeout)
-    ),
-  ]);
-};
+// Состояние подключения, которое присылает поллер
+interface ConnectionStatusData {
+  State: "connected" | "reconnecting" | "failed";
+  Attempt: number;
+  Error: string;
+}
 
 /**
  * Хук для работы с данными торрентов и управления соединением
@@ -52,6 +51,8 @@ export function useTorrentData() {
   );
   const [isInitialized, setIsInitialized] = useState(false);
   const [error, setError] = useState<string | null>(null);
+  // Информационное сообщение (например, о торренте-дубликате), скрывается само
+  const [notice, setNotice] = useState<string | null>(null);
   const [isReconnecting, setIsReconnecting] = useState(false);
   const [isLoading, setIsLoading] = useState(false);
   const [isFirstLoad, setIsFirstLoad] = useState(true);
@@ -112,14 +113,14 @@ export function useTorrentData() {
     }
   }, [isInitialized]);
 
-  // Функция для обновления списка торрентов с таймаутом
+  // Функция для обновления списка торрентов (таймауты запросов задаются на стороне Go)
   const refreshTorrents = useCallback(async () => {
     if (isFirstLoad) {
       setIsLoading(true); // Показываем спиннер загрузки торрентов только при первом запуске
     }
 
     try {
-      const response = await withTimeout(GetTorrents(), 1 * 60 * 1000, t); // Таймаут 1 минута
+      const response = await GetTorrents();
       setTorrents(response);
       setError(null);
       setIsReconnecting(false);
@@ -127,7 +128,7 @@ export function useTorrentData() {
     } catch (error) {
       console.error("Failed to fetch torrents:", error);
       setError(t("errors.timeoutExplanation"));
-      setIsReconnecting(true); // Устанавливаем реконнект только при таймауте
+      setIsReconnecting(true); // Устанавливаем реконнект при ошибке или таймауте запроса
     } finally {
       if (isFirstLoad) {
         setIsLoading(false); // Отключаем спиннер загрузки торрентов только при первом запуске
@@ -140,7 +141,7 @@ export function useTorrentData() {
     const initializeApp = async () => {
       setIsLoading(true); // Показываем спиннер загрузки торрентов при старте
       try {
-        const savedConfig = await withTimeout(LoadConfig(), 1 * 60 * 1000, t); // Таймаут 1 минута
+        const savedConfig = await LoadConfig();
         if (savedConfig) {
           const config: ConfigData = {
             ...savedConfig,
@@ -174,34 +175,72 @@ export function useTorrentData() {
     initializeApp();
   }, [refreshSessionStats, refreshTorrents, t]);
 
-  // Эффект для периодического обновления данных
+  // Подписка на обновления, которые присылает поллер на стороне Go
   useEffect(() => {
-    let torrentsInterval: number;
-    let statsInterval: number;
-
-    if (isInitialized) {
-      // Немедленно обновляем данные при инициализации
-      refreshSessionStats();
+    const unsubscribeTorrents = EventsOn(
+      "torrents-updated",
+      (data: TorrentData[]) => {
+        setTorrents(data);
+        setError(null);
+        setIsReconnecting(false);
+        setIsFirstLoad(false);
+        setIsInitialized(true);
+      }
+    );
+    const unsubscribeStats = EventsOn(
+      "session-stats",
+      (stats: SessionStatsData) => {
+        setSessionStats(stats);
+      }
+    );
+    const unsubscribeConnection = EventsOn(
+      "connection-state",
+      (status: ConnectionStatusData) => {
+        switch (status.State) {
+          case "connected":
+            setError(null);
+            setIsReconnecting(false);
+            break;
+          case "reconnecting":
+            setError(t("errors.timeoutExplanation"));
+            setIsReconnecting(true);
+            break;
+          case "failed":
+            setError(t("errors.maxReconnectAttempts"));
+            setIsReconnecting(false);
+            break;
+        }
+      }
+    );
 
-      // Устанавливаем интервалы обновления
-      statsInterval = window.setInterval(refreshSessionStats, 1000);
-      torrentsInterval = window.setInterval(refreshTorrents, 3000);
-    }
+    // При скрытом окне поллер опрашивает сервер реже
+    const handleVisibilityChange = () => {
+      SetWindowVisible(!document.hidden);
+    };
+    document.addEventListener("visibilitychange", handleVisibilityChange);
+    handleVisibilityChange();
 
     return () => {
-      if (statsInterval) {
-        window.clearInterval(statsInterval);
-      }
-      if (torrentsInterval) {
-        window.clearInterval(torrentsInterval);
-      }
+      unsubscribeTorrents();
+      unsubscribeStats();
+      unsubscribeConnection();
+      document.removeEventListener("visibilitychange", handleVisibilityChange);
     };
-  }, [isInitialized, refreshSessionStats, refreshTorrents]);
+  }, [t]);
+
+  useEffect(() => {
+    if (!notice) return;
+    const timer = setTimeout(() => setNotice(null), NOTICE_TIMEOUT_MS);
+    return () => clearTimeout(timer);
+  }, [notice]);
 
   // Обработчик добавления торрента
-  const handleAddTorrent = async (url: string, downloadDir: string = "") => {
+  const handleAddTorrent = async (url: string, options: AddTorrentOptions) => {
     try {
-      await AddTorrentAPI(url, downloadDir);
+      const result = await AddTorrentAPI(url, options);
+      if (result?.Status === "duplicate") {
+        setNotice(result.Message);
+      }
       refreshTorrents();
       return true;
     } catch (error) {
@@ -214,10 +253,13 @@ export function useTorrentData() {
   // Обработчик добавления торрента из файла
   const handleAddTorrentFile = async (
     base64Content: string,
-    downloadDir: string = ""
+    options: AddTorrentOptions
   ) => {
     try {
-      await AddTorrentFile(base64Content, downloadDir);
+      const result = await AddTorrentFile(base64Content, options);
+      if (result?.Status === "duplicate") {
+        setNotice(result.Message);
+      }
       refreshTorrents();
       return true;
     } catch (error) {
@@ -340,6 +382,7 @@ export function useTorrentData() {
     selectedTorrents,
     isInitialized,
     error,
+    notice,
     hasSelectedTorrents,
     sessionStats,
     isLoading: isLoading && isFirstLoad,
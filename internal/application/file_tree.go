@@ -0,0 +1,163 @@
+package application
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"transmission-client-go/internal/domain"
+	"transmission-client-go/internal/infrastructure/transmission"
+)
+
+// buildFileTree строит дерево папок из плоского списка файлов торрента.
+// Корневой узел не имеет имени и содержит папки и файлы верхнего уровня.
+func buildFileTree(files []domain.TorrentFile) *domain.FileTreeNode {
+	root := &domain.FileTreeNode{IsFolder: true, FileID: -1}
+	folders := map[string]*domain.FileTreeNode{"": root}
+
+	for _, file := range files {
+		parts := strings.Split(file.Path, "/")
+		parent := root
+		for i := 1; i < len(parts); i++ {
+			parent = folderNode(folders, parent, strings.Join(parts[:i], "/"), parts[i-1])
+		}
+
+		parent.Children = append(parent.Children, &domain.FileTreeNode{
+			Name:      parts[len(parts)-1],
+			Path:      file.Path,
+			FileID:    file.ID,
+			FileCount: 1,
+			Size:      file.Size,
+			Completed: file.Completed,
+			Progress:  file.Progress,
+			Wanted:    fileWantedState(file.Wanted),
+			Priority:  file.Priority,
+		})
+	}
+
+	aggregateFolder(root)
+	return root
+}
+
+// folderNode возвращает папку по пути, создавая ее при первом обращении
+func folderNode(folders map[string]*domain.FileTreeNode, parent *domain.FileTreeNode, path string, name string) *domain.FileTreeNode {
+	if node, ok := folders[path]; ok {
+		return node
+	}
+
+	node := &domain.FileTreeNode{Name: name, Path: path, IsFolder: true, FileID: -1}
+	folders[path] = node
+	parent.Children = append(parent.Children, node)
+	return node
+}
+
+// aggregateFolder рекурсивно подсчитывает размер, прогресс, выбор и приоритет папки
+// и сортирует вложенные узлы: сначала папки, затем файлы, по имени
+func aggregateFolder(folder *domain.FileTreeNode) {
+	var wantedCount int
+	var mixed bool
+	for i, child := range folder.Children {
+		if child.IsFolder {
+			aggregateFolder(child)
+		}
+
+		folder.FileCount += child.FileCount
+		folder.Size += child.Size
+		folder.Completed += child.Completed
+
+		switch child.Wanted {
+		case domain.WantedAll:
+			wantedCount += child.FileCount
+		case domain.WantedMixed:
+			mixed = true
+		}
+
+		if i == 0 {
+			folder.Priority = child.Priority
+		} else if folder.Priority != child.Priority {
+			folder.Priority = domain.FilePriorityMixed
+		}
+	}
+
+	switch {
+	case mixed:
+		folder.Wanted = domain.WantedMixed
+	case wantedCount == folder.FileCount:
+		folder.Wanted = domain.WantedAll
+	case wantedCount == 0:
+		folder.Wanted = domain.WantedNone
+	default:
+		folder.Wanted = domain.WantedMixed
+	}
+
+	if folder.Size > 0 {
+		folder.Progress = float64(folder.Completed) / float64(folder.Size) * 100
+	}
+
+	slices.SortFunc(folder.Children, func(a, b *domain.FileTreeNode) int {
+		if a.IsFolder != b.IsFolder {
+			if a.IsFolder {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+}
+
+// fileWantedState преобразует выбор отдельного файла
+func fileWantedState(wanted bool) domain.WantedState {
+	if wanted {
+		return domain.WantedAll
+	}
+	return domain.WantedNone
+}
+
+// filesUnderPath возвращает ID файлов, лежащих в папке или совпадающих с путем
+func filesUnderPath(files []domain.TorrentFile, path string) []int {
+	path = strings.Trim(path, "/")
+	var ids []int
+	for _, file := range files {
+		if path == "" || file.Path == path || strings.HasPrefix(file.Path, path+"/") {
+			ids = append(ids, file.ID)
+		}
+	}
+	return ids
+}
+
+// GetTorrentFileTree возвращает содержимое торрента в виде дерева папок
+func (s *TorrentService) GetTorrentFileTree(ctx context.Context, id int64) (*domain.FileTreeNode, error) {
+	files, err := s.repo.GetTorrentFiles(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return buildFileTree(files), nil
+}
+
+// SetFolderWanted включает или исключает из загрузки все файлы папки одним запросом
+func (s *TorrentService) SetFolderWanted(ctx context.Context, id int64, folderPath string, wanted bool) error {
+	files, err := s.repo.GetTorrentFiles(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	ids := filesUnderPath(files, folderPath)
+	if len(ids) == 0 {
+		return transmission.NewLocalizedError("errors.folderNotFound")
+	}
+	return s.repo.SetFilesWanted(ctx, id, ids, wanted)
+}
+
+// SetFolderPriority устанавливает приоритет всех файлов папки одним запросом
+func (s *TorrentService) SetFolderPriority(ctx context.Context, id int64, folderPath string, priority domain.FilePriority) error {
+	files, err := s.repo.GetTorrentFiles(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	ids := filesUnderPath(files, folderPath)
+	if len(ids) == 0 {
+		return transmission.NewLocalizedError("errors.folderNotFound")
+	}
+	return s.repo.SetFilesPriority(ctx, id, ids, priority)
+}
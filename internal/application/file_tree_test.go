@@ -0,0 +1,210 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"transmission-client-go/internal/domain"
+	"transmission-client-go/internal/infrastructure/transmission"
+)
+
+func treeTestFiles() []domain.TorrentFile {
+	return []domain.TorrentFile{
+		{ID: 0, Path: "album/cd2/01.flac", Size: 100, Completed: 100, Wanted: true, Priority: domain.FilePriorityNormal},
+		{ID: 1, Path: "album/cd1/02.flac", Size: 100, Completed: 50, Wanted: true, Priority: domain.FilePriorityNormal},
+		{ID: 2, Path: "album/cd1/01.flac", Size: 100, Completed: 0, Wanted: false, Priority: domain.FilePriorityHigh},
+		{ID: 3, Path: "album/cover.jpg", Size: 200, Completed: 200, Wanted: true, Priority: domain.FilePriorityNormal},
+		{ID: 4, Path: "album/cd10/01.flac", Size: 0, Wanted: false, Priority: domain.FilePriorityLow},
+	}
+}
+
+// findNode ищет узел дерева по пути
+func findNode(node *domain.FileTreeNode, path string) *domain.FileTreeNode {
+	if node.Path == path {
+		return node
+	}
+	for _, child := range node.Children {
+		if found := findNode(child, path); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func childNames(node *domain.FileTreeNode) []string {
+	var names []string
+	for _, child := range node.Children {
+		names = append(names, child.Name)
+	}
+	return names
+}
+
+func TestBuildFileTree(t *testing.T) {
+	root := buildFileTree(treeTestFiles())
+
+	tests := []struct {
+		path         string
+		wantFolder   bool
+		wantFiles    int
+		wantSize     int64
+		wantProgress float64
+		wantWanted   domain.WantedState
+		wantPriority domain.FilePriority
+	}{
+		{path: "", wantFolder: true, wantFiles: 5, wantSize: 500, wantProgress: 70, wantWanted: domain.WantedMixed, wantPriority: domain.FilePriorityMixed},
+		{path: "album", wantFolder: true, wantFiles: 5, wantSize: 500, wantProgress: 70, wantWanted: domain.WantedMixed, wantPriority: domain.FilePriorityMixed},
+		{path: "album/cd1", wantFolder: true, wantFiles: 2, wantSize: 200, wantProgress: 25, wantWanted: domain.WantedMixed, wantPriority: domain.FilePriorityMixed},
+		{path: "album/cd2", wantFolder: true, wantFiles: 1, wantSize: 100, wantProgress: 100, wantWanted: domain.WantedAll, wantPriority: domain.FilePriorityNormal},
+		{path: "album/cd10", wantFolder: true, wantFiles: 1, wantWanted: domain.WantedNone, wantPriority: domain.FilePriorityLow},
+		{path: "album/cd1/02.flac", wantFiles: 1, wantSize: 100, wantWanted: domain.WantedAll, wantPriority: domain.FilePriorityNormal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			node := findNode(root, tt.path)
+			if node == nil {
+				t.Fatalf("node %q not found", tt.path)
+			}
+			if node.IsFolder != tt.wantFolder || node.FileCount != tt.wantFiles || node.Size != tt.wantSize {
+				t.Errorf("folder = %v, files = %d, size = %d, want %v, %d, %d",
+					node.IsFolder, node.FileCount, node.Size, tt.wantFolder, tt.wantFiles, tt.wantSize)
+			}
+			if tt.wantFolder && node.Progress != tt.wantProgress {
+				t.Errorf("Progress = %v, want %v", node.Progress, tt.wantProgress)
+			}
+			if node.Wanted != tt.wantWanted || node.Priority != tt.wantPriority {
+				t.Errorf("Wanted = %q, Priority = %q, want %q, %q", node.Wanted, node.Priority, tt.wantWanted, tt.wantPriority)
+			}
+			if tt.wantFolder && node.FileID != -1 {
+				t.Errorf("FileID = %d, want -1 for a folder", node.FileID)
+			}
+		})
+	}
+
+	// Сначала папки, затем файлы, по имени
+	if names := childNames(findNode(root, "album")); !slices.Equal(names, []string{"cd1", "cd10", "cd2", "cover.jpg"}) {
+		t.Errorf("album children = %v", names)
+	}
+	if names := childNames(findNode(root, "album/cd1")); !slices.Equal(names, []string{"01.flac", "02.flac"}) {
+		t.Errorf("cd1 children = %v", names)
+	}
+}
+
+func TestAggregateFolder(t *testing.T) {
+	file := func(wanted domain.WantedState, priority domain.FilePriority) *domain.FileTreeNode {
+		return &domain.FileTreeNode{FileCount: 1, Size: 10, Wanted: wanted, Priority: priority}
+	}
+	folder := func(children ...*domain.FileTreeNode) *domain.FileTreeNode {
+		return &domain.FileTreeNode{IsFolder: true, FileID: -1, Children: children}
+	}
+
+	tests := []struct {
+		name         string
+		folder       *domain.FileTreeNode
+		wantWanted   domain.WantedState
+		wantPriority domain.FilePriority
+	}{
+		{
+			name:         "all wanted with the same priority",
+			folder:       folder(file(domain.WantedAll, domain.FilePriorityHigh), file(domain.WantedAll, domain.FilePriorityHigh)),
+			wantWanted:   domain.WantedAll,
+			wantPriority: domain.FilePriorityHigh,
+		},
+		{
+			name:         "none wanted",
+			folder:       folder(file(domain.WantedNone, domain.FilePriorityNormal), file(domain.WantedNone, domain.FilePriorityNormal)),
+			wantWanted:   domain.WantedNone,
+			wantPriority: domain.FilePriorityNormal,
+		},
+		{
+			name:         "mixed subfolder makes the parent mixed",
+			folder:       folder(file(domain.WantedAll, domain.FilePriorityLow), folder(file(domain.WantedAll, domain.FilePriorityLow), file(domain.WantedNone, domain.FilePriorityLow))),
+			wantWanted:   domain.WantedMixed,
+			wantPriority: domain.FilePriorityLow,
+		},
+		{
+			name:         "mixed subfolder priority makes the parent mixed",
+			folder:       folder(folder(file(domain.WantedAll, domain.FilePriorityLow), file(domain.WantedAll, domain.FilePriorityHigh))),
+			wantWanted:   domain.WantedAll,
+			wantPriority: domain.FilePriorityMixed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aggregateFolder(tt.folder)
+			if tt.folder.Wanted != tt.wantWanted || tt.folder.Priority != tt.wantPriority {
+				t.Errorf("Wanted = %q, Priority = %q, want %q, %q", tt.folder.Wanted, tt.folder.Priority, tt.wantWanted, tt.wantPriority)
+			}
+		})
+	}
+}
+
+func TestFilesUnderPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want []int
+	}{
+		{path: "", want: []int{0, 1, 2, 3, 4}},
+		{path: "album/cd1", want: []int{1, 2}},
+		{path: "/album/cd1/", want: []int{1, 2}},
+		{path: "album/cd1/01.flac", want: []int{2}},
+		{path: "album/cd", want: nil},
+		{path: "missing", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := filesUnderPath(treeTestFiles(), tt.path); !slices.Equal(got, tt.want) {
+				t.Errorf("filesUnderPath(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+// filesRepo отдает список файлов и запоминает изменения выбора и приоритета
+type filesRepo struct {
+	domain.TorrentRepository
+
+	wanted   []int
+	priority []int
+}
+
+func (r *filesRepo) GetTorrentFiles(ctx context.Context, id int64) ([]domain.TorrentFile, error) {
+	return treeTestFiles(), nil
+}
+
+func (r *filesRepo) SetFilesWanted(ctx context.Context, id int64, fileIDs []int, wanted bool) error {
+	r.wanted = fileIDs
+	return nil
+}
+
+func (r *filesRepo) SetFilesPriority(ctx context.Context, id int64, fileIDs []int, priority domain.FilePriority) error {
+	r.priority = fileIDs
+	return nil
+}
+
+func TestSetFolderSelection(t *testing.T) {
+	repo := &filesRepo{}
+	service := NewTorrentService(repo)
+	ctx := context.Background()
+
+	if err := service.SetFolderWanted(ctx, 1, "album/cd1", false); err != nil {
+		t.Fatalf("SetFolderWanted() error = %v", err)
+	}
+	if err := service.SetFolderPriority(ctx, 1, "album/cd2", domain.FilePriorityHigh); err != nil {
+		t.Fatalf("SetFolderPriority() error = %v", err)
+	}
+	if !slices.Equal(repo.wanted, []int{1, 2}) || !slices.Equal(repo.priority, []int{0}) {
+		t.Errorf("wanted = %v, priority = %v", repo.wanted, repo.priority)
+	}
+
+	var locErr *transmission.LocalizedError
+	if err := service.SetFolderWanted(ctx, 1, "album/missing", true); !errors.As(err, &locErr) || err.Error() != "errors.folderNotFound" {
+		t.Errorf("SetFolderWanted() error = %v, want errors.folderNotFound", err)
+	}
+	if err := service.SetFolderPriority(ctx, 1, "album/missing", domain.FilePriorityLow); !errors.As(err, &locErr) || err.Error() != "errors.folderNotFound" {
+		t.Errorf("SetFolderPriority() error = %v, want errors.folderNotFound", err)
+	}
+}
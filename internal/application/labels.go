@@ -0,0 +1,62 @@
+package application
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"transmission-client-go/internal/domain"
+)
+
+// SetTorrentLabels заменяет метки торрентов
+func (s *TorrentService) SetTorrentLabels(ctx context.Context, ids []int64, labels []string) error {
+	return s.repo.SetLabels(ctx, ids, labels)
+}
+
+// AddTorrentLabels добавляет метки к торрентам
+func (s *TorrentService) AddTorrentLabels(ctx context.Context, ids []int64, labels []string) error {
+	return s.repo.AddLabels(ctx, ids, labels)
+}
+
+// RemoveTorrentLabels снимает метки с торрентов
+func (s *TorrentService) RemoveTorrentLabels(ctx context.Context, ids []int64, labels []string) error {
+	return s.repo.RemoveLabels(ctx, ids, labels)
+}
+
+// GetLabelCounts возвращает все метки торрентов сервера с количеством торрентов.
+// Метки, отличающиеся только регистром, считаются одной меткой.
+func (s *TorrentService) GetLabelCounts(ctx context.Context) ([]domain.LabelCount, error) {
+	torrents, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return countLabels(torrents), nil
+}
+
+// countLabels подсчитывает торренты для каждой метки и сортирует метки по имени
+func countLabels(torrents []domain.Torrent) []domain.LabelCount {
+	index := make(map[string]int)
+	result := []domain.LabelCount{}
+	for _, t := range torrents {
+		seen := make(map[string]bool, len(t.Labels))
+		for _, label := range t.Labels {
+			key := strings.ToLower(label)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			i, ok := index[key]
+			if !ok {
+				i = len(result)
+				index[key] = i
+				result = append(result, domain.LabelCount{Name: label})
+			}
+			result[i].Count++
+		}
+	}
+
+	slices.SortFunc(result, func(a, b domain.LabelCount) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+	return result
+}
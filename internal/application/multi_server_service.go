@@ -0,0 +1,251 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"transmission-client-go/internal/domain"
+)
+
+const (
+	ErrServerNotFound = "server not found"
+	ErrNoServers      = "no servers configured"
+)
+
+// MultiServerService распределяет запросы между несколькими демонами Transmission
+// и объединяет их результаты. Недоступные серверы не мешают работе остальных.
+type MultiServerService struct {
+	mu      sync.RWMutex
+	servers map[string]domain.TorrentRepository
+	order   []string
+}
+
+func NewMultiServerService() *MultiServerService {
+	return &MultiServerService{
+		servers: make(map[string]domain.TorrentRepository),
+	}
+}
+
+// SetServer добавляет сервер или заменяет репозиторий существующего
+func (m *MultiServerService) SetServer(id string, repo domain.TorrentRepository) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.servers[id]; !exists {
+		m.order = append(m.order, id)
+	}
+	m.servers[id] = repo
+}
+
+// Reset удаляет все зарегистрированные серверы
+func (m *MultiServerService) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.servers = make(map[string]domain.TorrentRepository)
+	m.order = nil
+}
+
+// snapshot возвращает копию списка серверов, чтобы не держать блокировку во время запросов
+func (m *MultiServerService) snapshot() ([]string, map[string]domain.TorrentRepository) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ids := make([]string, len(m.order))
+	copy(ids, m.order)
+	servers := make(map[string]domain.TorrentRepository, len(m.servers))
+	for id, repo := range m.servers {
+		servers[id] = repo
+	}
+	return ids, servers
+}
+
+// fanOut параллельно вызывает fn для каждого сервера и возвращает ошибки по ID сервера
+func (m *MultiServerService) fanOut(fn func(id string, repo domain.TorrentRepository) error) ([]string, map[string]error) {
+	ids, servers := m.snapshot()
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		errMap = make(map[string]error)
+	)
+	for _, id := range ids {
+		wg.Add(1)
+		go func(id string, repo domain.TorrentRepository) {
+			defer wg.Done()
+			if err := fn(id, repo); err != nil {
+				mu.Lock()
+				errMap[id] = err
+				mu.Unlock()
+			}
+		}(id, servers[id])
+	}
+	wg.Wait()
+
+	return ids, errMap
+}
+
+// errorStrings преобразует ошибки серверов в строки для передачи во фронтенд
+func errorStrings(errMap map[string]error) map[string]string {
+	result := make(map[string]string, len(errMap))
+	for id, err := range errMap {
+		result[id] = err.Error()
+	}
+	return result
+}
+
+// GetAllTorrents возвращает объединенный список торрентов со всех серверов.
+// Ошибка возвращается только если не ответил ни один сервер.
+func (m *MultiServerService) GetAllTorrents(ctx context.Context) (*domain.AggregatedTorrents, error) {
+	var (
+		mu        sync.Mutex
+		perServer = make(map[string][]domain.Torrent)
+	)
+	ids, errMap := m.fanOut(func(id string, repo domain.TorrentRepository) error {
+		torrents, err := repo.GetAll(ctx)
+		if err != nil {
+			return err
+		}
+		for i := range torrents {
+			torrents[i].ServerID = id
+		}
+		mu.Lock()
+		perServer[id] = torrents
+		mu.Unlock()
+		return nil
+	})
+
+	if len(ids) == 0 {
+		return nil, errors.New(ErrNoServers)
+	}
+	if len(errMap) == len(ids) {
+		return nil, fmt.Errorf("all servers are unavailable: %w", joinServerErrors(errMap))
+	}
+
+	// Сохраняем порядок серверов из конфигурации
+	result := &domain.AggregatedTorrents{
+		Torrents: []domain.Torrent{},
+		Errors:   errorStrings(errMap),
+	}
+	for _, id := range ids {
+		result.Torrents = append(result.Torrents, perServer[id]...)
+	}
+	return result, nil
+}
+
+// GetSessionStats возвращает статистику по каждому серверу и их сумму
+func (m *MultiServerService) GetSessionStats(ctx context.Context) (*domain.AggregatedSessionStats, error) {
+	var (
+		mu        sync.Mutex
+		perServer = make(map[string]domain.SessionStats)
+	)
+	ids, errMap := m.fanOut(func(id string, repo domain.TorrentRepository) error {
+		stats, err := repo.GetSessionStats(ctx)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		perServer[id] = *stats
+		mu.Unlock()
+		return nil
+	})
+
+	if len(ids) == 0 {
+		return nil, errors.New(ErrNoServers)
+	}
+	if len(errMap) == len(ids) {
+		return nil, fmt.Errorf("all servers are unavailable: %w", joinServerErrors(errMap))
+	}
+
+	result := &domain.AggregatedSessionStats{
+		PerServer: perServer,
+		Errors:    errorStrings(errMap),
+	}
+	for _, id := range ids {
+		stats, ok := perServer[id]
+		if !ok {
+			continue
+		}
+		result.Total.TotalDownloadSpeed += stats.TotalDownloadSpeed
+		result.Total.TotalUploadSpeed += stats.TotalUploadSpeed
+		result.Total.FreeSpace += stats.FreeSpace
+	}
+	return result, nil
+}
+
+// groupRefs группирует ссылки на торренты по серверам
+func groupRefs(refs []domain.TorrentRef) map[string][]int64 {
+	grouped := make(map[string][]int64)
+	for _, ref := range refs {
+		grouped[ref.ServerID] = append(grouped[ref.ServerID], ref.ID)
+	}
+	return grouped
+}
+
+// forEachServer выполняет действие над торрентами, сгруппированными по серверам
+func (m *MultiServerService) forEachServer(refs []domain.TorrentRef, action func(repo domain.TorrentRepository, ids []int64) error) error {
+	_, servers := m.snapshot()
+
+	errMap := make(map[string]error)
+	for serverID, ids := range groupRefs(refs) {
+		repo, ok := servers[serverID]
+		if !ok {
+			errMap[serverID] = errors.New(ErrServerNotFound)
+			continue
+		}
+		if err := action(repo, ids); err != nil {
+			errMap[serverID] = err
+		}
+	}
+	return joinServerErrors(errMap)
+}
+
+// joinServerErrors объединяет ошибки серверов в одну, указывая ID сервера
+func joinServerErrors(errMap map[string]error) error {
+	var errs []error
+	for id, err := range errMap {
+		errs = append(errs, fmt.Errorf("server %s: %w", id, err))
+	}
+	return errors.Join(errs...)
+}
+
+// StartTorrents запускает торренты на соответствующих серверах
+func (m *MultiServerService) StartTorrents(ctx context.Context, refs []domain.TorrentRef) error {
+	return m.forEachServer(refs, func(repo domain.TorrentRepository, ids []int64) error {
+		return repo.Start(ctx, ids)
+	})
+}
+
+// StopTorrents останавливает торренты на соответствующих серверах
+func (m *MultiServerService) StopTorrents(ctx context.Context, refs []domain.TorrentRef) error {
+	return m.forEachServer(refs, func(repo domain.TorrentRepository, ids []int64) error {
+		return repo.Stop(ctx, ids)
+	})
+}
+
+// RemoveTorrents удаляет торренты на соответствующих серверах
+func (m *MultiServerService) RemoveTorrents(ctx context.Context, refs []domain.TorrentRef, deleteData bool) error {
+	return m.forEachServer(refs, func(repo domain.TorrentRepository, ids []int64) error {
+		var errs []error
+		for _, id := range ids {
+			if err := repo.Remove(ctx, id, deleteData); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	})
+}
+
+// VerifyTorrents запускает проверку торрентов на соответствующих серверах
+func (m *MultiServerService) VerifyTorrents(ctx context.Context, refs []domain.TorrentRef) error {
+	return m.forEachServer(refs, func(repo domain.TorrentRepository, ids []int64) error {
+		var errs []error
+		for _, id := range ids {
+			if err := repo.VerifyTorrent(ctx, id); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	})
+}
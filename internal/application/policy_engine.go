@@ -0,0 +1,304 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"transmission-client-go/internal/domain"
+)
+
+const (
+	EventPolicyAction = "policy-action"
+
+	DefaultPolicyInterval = time.Minute
+	maxPolicyLogEntries   = 500
+
+	// legacyRatioRuleID правило, которое строится из Config.MaxUploadRatio
+	legacyRatioRuleID = "max-upload-ratio"
+
+	ErrUnknownPolicyAction = "unknown policy action"
+	ErrEmptyMoveLocation   = "move location cannot be empty"
+	ErrEmptyPolicyRuleID   = "policy rule id cannot be empty"
+)
+
+// PolicyEngine по таймеру применяет правила обслуживания к завершенным торрентам
+// текущего подключения и записывает каждое действие в журнал
+type PolicyEngine struct {
+	emit     EventEmitter
+	interval time.Duration
+	wake     chan struct{}
+
+	mu      sync.Mutex
+	ctx     context.Context
+	service *TorrentService
+
+	logMu sync.RWMutex
+	log   []domain.PolicyLogEntry
+}
+
+func NewPolicyEngine(emit EventEmitter, interval time.Duration) *PolicyEngine {
+	return &PolicyEngine{
+		emit:     emit,
+		interval: interval,
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// SetSource задает сервис, к торрентам которого применяются правила
+func (e *PolicyEngine) SetSource(ctx context.Context, service *TorrentService) {
+	e.mu.Lock()
+	e.ctx = ctx
+	e.service = service
+	e.mu.Unlock()
+
+	e.Trigger()
+}
+
+// Trigger запускает внеочередную проверку правил
+func (e *PolicyEngine) Trigger() {
+	select {
+	case e.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run проверяет правила с заданным интервалом до отмены контекста
+func (e *PolicyEngine) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-e.wake:
+		case <-ticker.C:
+		}
+
+		e.mu.Lock()
+		sourceCtx, service := e.ctx, e.service
+		e.mu.Unlock()
+
+		if service != nil {
+			_ = e.Evaluate(sourceCtx, service)
+		}
+	}
+}
+
+// Log возвращает журнал действий, от старых записей к новым
+func (e *PolicyEngine) Log() []domain.PolicyLogEntry {
+	e.logMu.RLock()
+	defer e.logMu.RUnlock()
+
+	return slices.Clone(e.log)
+}
+
+// ClearLog очищает журнал действий
+func (e *PolicyEngine) ClearLog() {
+	e.logMu.Lock()
+	defer e.logMu.Unlock()
+
+	e.log = nil
+}
+
+// Evaluate однократно применяет правила ко всем торрентам из снимка поллера.
+// К каждому торренту применяется только первое сработавшее правило.
+func (e *PolicyEngine) Evaluate(ctx context.Context, service *TorrentService) error {
+	rules := service.PolicyRules()
+	if len(rules) == 0 {
+		return nil
+	}
+
+	// Торренты берутся из снимка поллера, чтобы не запрашивать полный список отдельно
+	torrents, ok := service.loadedSnapshot()
+	if !ok {
+		return nil
+	}
+
+	now := time.Now()
+	for _, t := range torrents {
+		rule, ok := firstMatchingPolicyRule(rules, t, now)
+		if !ok || !policyActionNeeded(service, rule, t) {
+			continue
+		}
+		e.record(rule, t, service.applyPolicyAction(ctx, rule, t))
+	}
+	return nil
+}
+
+// firstMatchingPolicyRule возвращает первое включенное правило, под которое подходит торрент
+func firstMatchingPolicyRule(rules []domain.PolicyRule, t domain.Torrent, now time.Time) (domain.PolicyRule, bool) {
+	for _, rule := range rules {
+		if rule.Enabled && policyRuleMatches(rule, t, now) {
+			return rule, true
+		}
+	}
+	return domain.PolicyRule{}, false
+}
+
+// record добавляет запись в журнал и сообщает о ней фронтенду
+func (e *PolicyEngine) record(rule domain.PolicyRule, t domain.Torrent, err error) {
+	entry := domain.PolicyLogEntry{
+		Time:        time.Now().Unix(),
+		RuleID:      rule.ID,
+		RuleName:    rule.Name,
+		TorrentID:   t.ID,
+		TorrentName: t.Name,
+		Action:      rule.Action,
+		Success:     err == nil,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	e.logMu.Lock()
+	e.log = append(e.log, entry)
+	if len(e.log) > maxPolicyLogEntries {
+		e.log = slices.Delete(e.log, 0, len(e.log)-maxPolicyLogEntries)
+	}
+	e.logMu.Unlock()
+
+	if e.emit != nil {
+		e.emit(EventPolicyAction, entry)
+	}
+}
+
+// policyRuleMatches проверяет условия и исключения правила для завершенного торрента
+func policyRuleMatches(rule domain.PolicyRule, t domain.Torrent, now time.Time) bool {
+	if t.Progress < 100 {
+		return false
+	}
+	if rule.MinRatio > 0 && t.UploadRatio < rule.MinRatio {
+		return false
+	}
+	if rule.MinAgeHours > 0 {
+		if t.AddedDate == 0 || now.Sub(time.Unix(t.AddedDate, 0)) < time.Duration(rule.MinAgeHours)*time.Hour {
+			return false
+		}
+	}
+	if len(rule.Labels) > 0 && !hasAnyLabel(t, rule.Labels) {
+		return false
+	}
+	if len(rule.Trackers) > 0 && !hasAnyTracker(t, rule.Trackers) {
+		return false
+	}
+	if hasAnyLabel(t, rule.ExemptLabels) || hasAnyTracker(t, rule.ExemptTrackers) {
+		return false
+	}
+	return true
+}
+
+// policyActionNeeded отсеивает действия, результат которых уже достигнут
+func policyActionNeeded(service *TorrentService, rule domain.PolicyRule, t domain.Torrent) bool {
+	switch rule.Action {
+	case domain.PolicyActionStop:
+		return t.Status != domain.StatusStopped && t.Status != domain.StatusCompleted
+	case domain.PolicyActionMove:
+		return filepath.Clean(t.DownloadDir) != filepath.Clean(rule.MoveLocation) && !service.moves.has(t.ID)
+	default:
+		return true
+	}
+}
+
+// hasAnyLabel проверяет, есть ли у торрента одна из меток (без учета регистра)
+func hasAnyLabel(t domain.Torrent, labels []string) bool {
+	for _, label := range t.Labels {
+		for _, want := range labels {
+			if strings.EqualFold(label, want) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasAnyTracker проверяет, содержит ли адрес одного из трекеров торрента указанную строку
+func hasAnyTracker(t domain.Torrent, patterns []string) bool {
+	for _, tracker := range t.Trackers {
+		announce := strings.ToLower(tracker.Announce)
+		if parsed, err := url.Parse(tracker.Announce); err == nil && parsed.Host != "" {
+			announce = strings.ToLower(parsed.Host)
+		}
+		for _, pattern := range patterns {
+			if pattern != "" && strings.Contains(announce, strings.ToLower(pattern)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PolicyRules возвращает правила, переданные сервису последним UpdateConfig
+func (s *TorrentService) PolicyRules() []domain.PolicyRule {
+	s.rulesMu.RLock()
+	defer s.rulesMu.RUnlock()
+
+	return s.policyRules
+}
+
+// policyRulesFromConfig копирует правила из конфигурации и добавляет правило
+// остановки по максимальному рейтингу из общих настроек
+func policyRulesFromConfig(config *domain.Config) []domain.PolicyRule {
+	if config == nil {
+		return nil
+	}
+
+	rules := make([]domain.PolicyRule, 0, len(config.PolicyRules)+1)
+	for _, rule := range config.PolicyRules {
+		rule.Labels = slices.Clone(rule.Labels)
+		rule.Trackers = slices.Clone(rule.Trackers)
+		rule.ExemptLabels = slices.Clone(rule.ExemptLabels)
+		rule.ExemptTrackers = slices.Clone(rule.ExemptTrackers)
+		rules = append(rules, rule)
+	}
+	if config.MaxUploadRatio > 0 {
+		rules = append(rules, domain.PolicyRule{
+			ID:       legacyRatioRuleID,
+			Name:     "Max upload ratio",
+			Enabled:  true,
+			MinRatio: config.MaxUploadRatio,
+			Action:   domain.PolicyActionStop,
+		})
+	}
+	return rules
+}
+
+// ValidatePolicyRule проверяет, что правило можно выполнить
+func ValidatePolicyRule(rule domain.PolicyRule) error {
+	if rule.ID == "" {
+		return errors.New(ErrEmptyPolicyRuleID)
+	}
+
+	switch rule.Action {
+	case domain.PolicyActionStop, domain.PolicyActionRemove, domain.PolicyActionRemoveWithData:
+		return nil
+	case domain.PolicyActionMove:
+		if strings.TrimSpace(rule.MoveLocation) == "" {
+			return errors.New(ErrEmptyMoveLocation)
+		}
+		return nil
+	default:
+		return errors.New(ErrUnknownPolicyAction)
+	}
+}
+
+// applyPolicyAction выполняет действие правила над торрентом
+func (s *TorrentService) applyPolicyAction(ctx context.Context, rule domain.PolicyRule, t domain.Torrent) error {
+	switch rule.Action {
+	case domain.PolicyActionStop:
+		return s.repo.Stop(ctx, []int64{t.ID})
+	case domain.PolicyActionRemove:
+		return s.repo.Remove(ctx, t.ID, false)
+	case domain.PolicyActionRemoveWithData:
+		return s.repo.Remove(ctx, t.ID, true)
+	case domain.PolicyActionMove:
+		return s.moveTorrents(ctx, []int64{t.ID}, rule.MoveLocation, true)
+	default:
+		return errors.New(ErrUnknownPolicyAction)
+	}
+}
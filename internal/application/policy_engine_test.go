@@ -0,0 +1,108 @@
+package application
+
+import (
+	"testing"
+	"time"
+	"transmission-client-go/internal/domain"
+)
+
+func TestFirstMatchingPolicyRule(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	dayAgo := now.Add(-24 * time.Hour).Unix()
+
+	ratioRule := domain.PolicyRule{ID: "ratio", Enabled: true, MinRatio: 2, Action: domain.PolicyActionStop}
+	labelRule := domain.PolicyRule{ID: "label", Enabled: true, Labels: []string{"Movies"}, Action: domain.PolicyActionMove, MoveLocation: "/movies"}
+	ageRule := domain.PolicyRule{ID: "age", Enabled: true, MinAgeHours: 12, Action: domain.PolicyActionRemove}
+	disabledRule := domain.PolicyRule{ID: "disabled", Enabled: false, Action: domain.PolicyActionRemoveWithData}
+	exemptRule := domain.PolicyRule{ID: "exempt", Enabled: true, ExemptTrackers: []string{"private.example"}, Action: domain.PolicyActionStop}
+
+	tests := []struct {
+		name    string
+		rules   []domain.PolicyRule
+		torrent domain.Torrent
+		want    string
+	}{
+		{
+			name:    "incomplete torrent is never matched",
+			rules:   []domain.PolicyRule{exemptRule},
+			torrent: domain.Torrent{Progress: 99.9},
+		},
+		{
+			name:    "first matching rule wins",
+			rules:   []domain.PolicyRule{ratioRule, labelRule, ageRule},
+			torrent: domain.Torrent{Progress: 100, UploadRatio: 3, Labels: []string{"movies"}, AddedDate: dayAgo},
+			want:    "ratio",
+		},
+		{
+			name:    "later rule matches when earlier ones do not",
+			rules:   []domain.PolicyRule{ratioRule, labelRule, ageRule},
+			torrent: domain.Torrent{Progress: 100, UploadRatio: 1, AddedDate: dayAgo},
+			want:    "age",
+		},
+		{
+			name:    "labels are compared case-insensitively",
+			rules:   []domain.PolicyRule{ratioRule, labelRule},
+			torrent: domain.Torrent{Progress: 100, Labels: []string{"MOVIES"}},
+			want:    "label",
+		},
+		{
+			name:    "disabled rule is skipped",
+			rules:   []domain.PolicyRule{disabledRule, ageRule},
+			torrent: domain.Torrent{Progress: 100, AddedDate: dayAgo},
+			want:    "age",
+		},
+		{
+			name:    "unknown added date does not satisfy age",
+			rules:   []domain.PolicyRule{ageRule},
+			torrent: domain.Torrent{Progress: 100},
+		},
+		{
+			name:  "exempt tracker is skipped",
+			rules: []domain.PolicyRule{exemptRule},
+			torrent: domain.Torrent{Progress: 100, Trackers: []domain.TrackerAnnounce{
+				{Announce: "https://tracker.private.example/announce"},
+			}},
+		},
+		{
+			name:    "no rules",
+			torrent: domain.Torrent{Progress: 100},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, ok := firstMatchingPolicyRule(tt.rules, tt.torrent, now)
+			if ok != (tt.want != "") {
+				t.Fatalf("matched = %v, want %v", ok, tt.want != "")
+			}
+			if rule.ID != tt.want {
+				t.Errorf("rule = %q, want %q", rule.ID, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicyRulesFromConfig(t *testing.T) {
+	config := &domain.Config{
+		MaxUploadRatio: 1.5,
+		PolicyRules:    []domain.PolicyRule{{ID: "a", Labels: []string{"x"}}},
+	}
+
+	rules := policyRulesFromConfig(config)
+	if len(rules) != 2 || rules[0].ID != "a" || rules[1].ID != legacyRatioRuleID {
+		t.Fatalf("rules = %+v", rules)
+	}
+	if rules[1].MinRatio != 1.5 || rules[1].Action != domain.PolicyActionStop {
+		t.Errorf("legacy ratio rule = %+v", rules[1])
+	}
+
+	// Изменение конфигурации после копирования не затрагивает правила движка
+	config.PolicyRules[0].Labels[0] = "y"
+	if rules[0].Labels[0] != "x" {
+		t.Errorf("rules share labels with config")
+	}
+
+	if rules := policyRulesFromConfig(nil); rules != nil {
+		t.Errorf("nil config rules = %+v", rules)
+	}
+}
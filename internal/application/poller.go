@@ -0,0 +1,233 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+	"transmission-client-go/internal/domain"
+)
+
+const (
+	EventTorrentsUpdated = "torrents-updated"
+	EventTorrentsDiff    = "torrents-diff"
+	EventSessionStats    = "session-stats"
+	EventConnectionState = "connection-state"
+
+	DefaultPollInterval = 2 * time.Second
+	MinPollInterval     = 500 * time.Millisecond
+	MaxPollInterval     = 60 * time.Second
+
+	hiddenIntervalFactor = 5                // Во сколько раз реже опрашиваем при скрытом окне
+	maxBackoffInterval   = 60 * time.Second // Максимальная пауза между попытками переподключения
+	failedAfterAttempts  = 5                // После скольких неудач подряд считаем подключение потерянным
+)
+
+// EventEmitter отправляет событие во фронтенд
+type EventEmitter func(name string, data any)
+
+// UpdateListener получает изменения снимка после каждого успешного опроса
+type UpdateListener func(service *TorrentService, diff *domain.TorrentDiff)
+
+// Poller периодически запрашивает торренты и статистику сессии и отправляет их событиями.
+// Интервал увеличивается, когда окно скрыто или сервер недоступен.
+type Poller struct {
+	emit EventEmitter
+	wake chan struct{}
+
+	mu        sync.Mutex
+	ctx       context.Context
+	service   *TorrentService
+	interval  time.Duration
+	hidden    bool
+	failures  int
+	connected bool
+	listeners []UpdateListener
+}
+
+func NewPoller(emit EventEmitter) *Poller {
+	return &Poller{
+		emit:     emit,
+		wake:     make(chan struct{}, 1),
+		interval: DefaultPollInterval,
+	}
+}
+
+// SetSource задает сервис для опроса и контекст текущего подключения
+func (p *Poller) SetSource(ctx context.Context, service *TorrentService) {
+	p.mu.Lock()
+	p.ctx = ctx
+	p.service = service
+	p.failures = 0
+	p.connected = false
+	p.mu.Unlock()
+
+	// После переподключения изменения за последнюю минуту ничего не говорят о прошедшем времени
+	if service != nil {
+		service.InvalidateSnapshot()
+	}
+	p.Trigger()
+}
+
+// OnUpdate подписывает listener на изменения снимка, чтобы фоновые движки
+// не запрашивали торренты отдельно
+func (p *Poller) OnUpdate(listener UpdateListener) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.listeners = append(p.listeners, listener)
+}
+
+// SetInterval меняет базовый интервал опроса
+func (p *Poller) SetInterval(interval time.Duration) error {
+	if interval < MinPollInterval || interval > MaxPollInterval {
+		return fmt.Errorf("polling interval must be between %v and %v", MinPollInterval, MaxPollInterval)
+	}
+
+	p.mu.Lock()
+	p.interval = interval
+	p.mu.Unlock()
+
+	p.Trigger()
+	return nil
+}
+
+// SetHidden сообщает поллеру, видно ли окно приложения
+func (p *Poller) SetHidden(hidden bool) {
+	p.mu.Lock()
+	wasHidden := p.hidden
+	p.hidden = hidden
+	p.mu.Unlock()
+
+	// При возврате окна сразу обновляем данные
+	if wasHidden && !hidden {
+		p.Trigger()
+	}
+}
+
+// Trigger запускает внеочередной опрос
+func (p *Poller) Trigger() {
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run выполняет цикл опроса до отмены контекста
+func (p *Poller) Run(ctx context.Context) {
+	for {
+		p.poll()
+
+		timer := time.NewTimer(p.nextDelay())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-p.wake:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// nextDelay вычисляет паузу до следующего опроса с учетом видимости окна и ошибок
+func (p *Poller) nextDelay() time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delay := p.interval
+	if p.hidden {
+		delay *= hiddenIntervalFactor
+	}
+
+	// Экспоненциальная задержка при недоступности сервера
+	for i := 0; i < p.failures && delay < maxBackoffInterval; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxBackoffInterval)
+}
+
+// poll выполняет один цикл опроса
+func (p *Poller) poll() {
+	p.mu.Lock()
+	ctx, service := p.ctx, p.service
+	p.mu.Unlock()
+
+	if service == nil {
+		return
+	}
+
+	diff, err := service.GetTorrentUpdates(ctx)
+	if err != nil {
+		p.handleFailure(ctx, service, err)
+		return
+	}
+
+	stats, err := service.GetSessionStats(ctx)
+	if err != nil {
+		p.handleFailure(ctx, service, err)
+		return
+	}
+
+	p.handleSuccess(service)
+	p.emit(EventTorrentsUpdated, service.SnapshotTorrents())
+	p.emit(EventTorrentsDiff, diff)
+	p.emit(EventSessionStats, stats)
+
+	p.mu.Lock()
+	listeners := slices.Clone(p.listeners)
+	p.mu.Unlock()
+	for _, listener := range listeners {
+		listener(service, diff)
+	}
+}
+
+// handleSuccess сбрасывает счетчик ошибок и сообщает о восстановлении подключения
+func (p *Poller) handleSuccess(service *TorrentService) {
+	p.mu.Lock()
+	if p.service != service {
+		p.mu.Unlock()
+		return
+	}
+	wasConnected := p.connected
+	p.connected = true
+	p.failures = 0
+	p.mu.Unlock()
+
+	if !wasConnected {
+		p.emit(EventConnectionState, domain.ConnectionStatus{State: domain.ConnectionConnected})
+	}
+}
+
+// handleFailure увеличивает счетчик ошибок и сообщает о переподключении или потере связи
+func (p *Poller) handleFailure(ctx context.Context, service *TorrentService, err error) {
+	// Запрос отменен из-за смены профиля или завершения приложения
+	if ctx != nil && ctx.Err() == context.Canceled {
+		return
+	}
+
+	p.mu.Lock()
+	if p.service != service {
+		p.mu.Unlock()
+		return
+	}
+	p.connected = false
+	p.failures++
+	attempt := p.failures
+	p.mu.Unlock()
+
+	// Пока сервер недоступен, изменения могут выйти за окно недавно активных торрентов
+	service.InvalidateSnapshot()
+
+	state := domain.ConnectionReconnecting
+	if attempt >= failedAfterAttempts {
+		state = domain.ConnectionFailed
+	}
+	p.emit(EventConnectionState, domain.ConnectionStatus{
+		State:   state,
+		Attempt: attempt,
+		Error:   err.Error(),
+	})
+}
@@ -0,0 +1,89 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"transmission-client-go/internal/domain"
+)
+
+// fakeRepo отдает заданный список торрентов и считает полные и частичные запросы
+type fakeRepo struct {
+	domain.TorrentRepository
+
+	torrents   []domain.Torrent
+	err        error
+	fullCalls  int
+	deltaCalls int
+}
+
+func (r *fakeRepo) GetAll(ctx context.Context) ([]domain.Torrent, error) {
+	r.fullCalls++
+	if r.err != nil {
+		return nil, r.err
+	}
+	return slices.Clone(r.torrents), nil
+}
+
+func (r *fakeRepo) GetRecentlyActive(ctx context.Context) ([]domain.Torrent, []int64, error) {
+	r.deltaCalls++
+	if r.err != nil {
+		return nil, nil, r.err
+	}
+	return nil, nil, nil
+}
+
+func (r *fakeRepo) GetSessionStats(ctx context.Context) (*domain.SessionStats, error) {
+	return &domain.SessionStats{}, r.err
+}
+
+func TestPollerResyncsAfterFailure(t *testing.T) {
+	repo := &fakeRepo{torrents: []domain.Torrent{{ID: 1}, {ID: 2}}}
+	service := NewTorrentService(repo)
+
+	var diffs []*domain.TorrentDiff
+	poller := NewPoller(func(string, any) {})
+	poller.OnUpdate(func(_ *TorrentService, diff *domain.TorrentDiff) {
+		diffs = append(diffs, diff)
+	})
+	poller.SetSource(context.Background(), service)
+
+	poller.poll()
+	poller.poll()
+	if repo.fullCalls != 1 || repo.deltaCalls != 1 {
+		t.Fatalf("full = %d, delta = %d, want one of each", repo.fullCalls, repo.deltaCalls)
+	}
+
+	// Во время недоступности сервера торрент удален; дельта этого не покажет
+	repo.err = errors.New("connection refused")
+	poller.poll()
+	repo.err = nil
+	repo.torrents = []domain.Torrent{{ID: 1}}
+	repo.fullCalls, repo.deltaCalls = 0, 0
+
+	poller.poll()
+	if repo.fullCalls != 1 || repo.deltaCalls != 0 {
+		t.Fatalf("after failure: full = %d, delta = %d, want a full sync", repo.fullCalls, repo.deltaCalls)
+	}
+	last := diffs[len(diffs)-1]
+	if !last.Full || !slices.Equal(last.Removed, []int64{2}) {
+		t.Errorf("diff = %+v, want a full diff removing torrent 2", last)
+	}
+}
+
+func TestPollerResyncsOnNewSource(t *testing.T) {
+	repo := &fakeRepo{torrents: []domain.Torrent{{ID: 1}}}
+	service := NewTorrentService(repo)
+
+	poller := NewPoller(func(string, any) {})
+	poller.SetSource(context.Background(), service)
+	poller.poll()
+
+	// Повторное подключение того же сервиса начинается с полной синхронизации
+	poller.SetSource(context.Background(), service)
+	poller.poll()
+	if repo.fullCalls != 2 || repo.deltaCalls != 0 {
+		t.Errorf("full = %d, delta = %d, want two full syncs", repo.fullCalls, repo.deltaCalls)
+	}
+}
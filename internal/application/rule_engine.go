@@ -0,0 +1,609 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"transmission-client-go/internal/domain"
+)
+
+const (
+	EventRuleAction = "rule-action"
+
+	DefaultRuleInterval = 30 * time.Second
+	maxRuleLogEntries   = 500
+
+	ErrEmptyRuleID          = "rule id cannot be empty"
+	ErrRuleWithoutCondition = "rule must have at least one condition"
+	ErrRuleWithoutAction    = "rule must have at least one action"
+	ErrUnknownCondition     = "unknown rule condition"
+	ErrUnknownOperator      = "unknown rule operator"
+	ErrUnknownRuleAction    = "unknown rule action"
+)
+
+// ruleMatchState состояние торрента относительно правила
+type ruleMatchState struct {
+	since time.Time // Когда торрент начал удовлетворять условиям
+	fired bool      // Действия уже выполнены для текущего перехода
+}
+
+// RuleEngine отслеживает изменения торрентов из снимка поллера и выполняет действия правил,
+// когда торрент переходит в состояние, удовлетворяющее условиям
+type RuleEngine struct {
+	emit     EventEmitter
+	interval time.Duration
+	wake     chan struct{} // Полная проверка снимка
+	updated  chan struct{} // Проверка изменений, полученных от поллера
+
+	mu      sync.Mutex
+	ctx     context.Context
+	service *TorrentService
+	pending *ruleUpdate                          // Изменения от поллера, еще не обработанные движком
+	states  map[string]map[int64]*ruleMatchState // ID правила -> ID торрента -> состояние
+	primed  map[string]bool                      // Правила, для которых уже получен первый снимок
+	regexps map[string]*regexp.Regexp
+
+	logMu sync.RWMutex
+	log   []domain.RuleLogEntry
+}
+
+// ruleUpdate изменения снимка, накопленные между проверками
+type ruleUpdate struct {
+	changed map[int64]domain.Torrent
+	removed []int64
+}
+
+func NewRuleEngine(emit EventEmitter, interval time.Duration) *RuleEngine {
+	return &RuleEngine{
+		emit:     emit,
+		interval: interval,
+		wake:     make(chan struct{}, 1),
+		updated:  make(chan struct{}, 1),
+		states:   make(map[string]map[int64]*ruleMatchState),
+		primed:   make(map[string]bool),
+		regexps:  make(map[string]*regexp.Regexp),
+	}
+}
+
+// SetSource задает сервис, к торрентам которого применяются правила.
+// Состояние переходов сбрасывается: первый снимок нового сервера служит точкой отсчета.
+func (e *RuleEngine) SetSource(ctx context.Context, service *TorrentService) {
+	e.mu.Lock()
+	e.ctx = ctx
+	e.service = service
+	e.pending = nil
+	e.states = make(map[string]map[int64]*ruleMatchState)
+	e.primed = make(map[string]bool)
+	e.mu.Unlock()
+
+	e.Trigger()
+}
+
+// Trigger запускает внеочередную проверку правил
+func (e *RuleEngine) Trigger() {
+	select {
+	case e.wake <- struct{}{}:
+	default:
+	}
+}
+
+// HandleUpdate принимает изменения снимка от поллера. Проверка выполняется
+// в цикле движка, чтобы действия правил не задерживали опрос.
+func (e *RuleEngine) HandleUpdate(service *TorrentService, diff *domain.TorrentDiff) {
+	if len(diff.Added) == 0 && len(diff.Changed) == 0 && len(diff.Removed) == 0 {
+		return
+	}
+
+	e.mu.Lock()
+	if e.service != service {
+		e.mu.Unlock()
+		return
+	}
+	if e.pending == nil {
+		e.pending = &ruleUpdate{changed: make(map[int64]domain.Torrent)}
+	}
+	for _, t := range slices.Concat(diff.Added, diff.Changed) {
+		e.pending.changed[t.ID] = t
+	}
+	for _, id := range diff.Removed {
+		delete(e.pending.changed, id)
+		e.pending.removed = append(e.pending.removed, id)
+	}
+	e.mu.Unlock()
+
+	select {
+	case e.updated <- struct{}{}:
+	default:
+	}
+}
+
+// Run проверяет изменения от поллера по мере поступления, а весь снимок - с заданным
+// интервалом, чтобы учесть условия, зависящие от времени (возраст, ForMinutes)
+func (e *RuleEngine) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		full := true
+		select {
+		case <-ctx.Done():
+			return
+		case <-e.wake:
+		case <-ticker.C:
+		case <-e.updated:
+			full = false
+		}
+
+		e.mu.Lock()
+		sourceCtx, service, update := e.ctx, e.service, e.pending
+		e.pending = nil
+		e.mu.Unlock()
+
+		switch {
+		case service == nil:
+		case full:
+			_ = e.Evaluate(sourceCtx, service)
+		case update != nil:
+			e.evaluate(sourceCtx, service, slices.Collect(maps.Values(update.changed)), update.removed, false)
+		}
+	}
+}
+
+// Log возвращает журнал срабатываний, от старых записей к новым
+func (e *RuleEngine) Log() []domain.RuleLogEntry {
+	e.logMu.RLock()
+	defer e.logMu.RUnlock()
+
+	return slices.Clone(e.log)
+}
+
+// ClearLog очищает журнал срабатываний
+func (e *RuleEngine) ClearLog() {
+	e.logMu.Lock()
+	defer e.logMu.Unlock()
+
+	e.log = nil
+}
+
+// Evaluate проверяет правила по всему снимку поллера, определяет переходы и выполняет действия
+func (e *RuleEngine) Evaluate(ctx context.Context, service *TorrentService) error {
+	torrents, ok := service.loadedSnapshot()
+	if !ok {
+		return nil
+	}
+	e.evaluate(ctx, service, torrents, nil, true)
+	return nil
+}
+
+// evaluate определяет переходы для переданных торрентов и выполняет действия правил.
+// При полной проверке torrents содержит весь снимок, и отсутствующие в нем торренты забываются;
+// иначе забываются только removed, а правила без первого полного снимка пропускаются.
+func (e *RuleEngine) evaluate(ctx context.Context, service *TorrentService, torrents []domain.Torrent, removed []int64, full bool) {
+	rules := service.AutomationRules()
+
+	type firing struct {
+		rule    domain.AutomationRule
+		torrent domain.Torrent
+	}
+	var toFire []firing
+
+	now := time.Now()
+	e.mu.Lock()
+	if e.service != service {
+		e.mu.Unlock()
+		return
+	}
+
+	active := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		if !rule.Enabled {
+			continue
+		}
+		active[rule.ID] = true
+		if !full && !e.primed[rule.ID] {
+			continue
+		}
+
+		states := e.states[rule.ID]
+		if states == nil {
+			states = make(map[int64]*ruleMatchState)
+			e.states[rule.ID] = states
+		}
+
+		present := make(map[int64]bool, len(torrents))
+		for _, t := range torrents {
+			present[t.ID] = true
+			if !e.matches(rule, t, now) {
+				delete(states, t.ID)
+				continue
+			}
+
+			state, seen := states[t.ID]
+			if !seen {
+				// В первом снимке для правила торренты, уже удовлетворяющие условиям, не считаются перешедшими
+				state = &ruleMatchState{since: now, fired: !e.primed[rule.ID] && rule.ForMinutes == 0}
+				states[t.ID] = state
+			}
+			if !state.fired && now.Sub(state.since) >= time.Duration(rule.ForMinutes)*time.Minute {
+				state.fired = true
+				toFire = append(toFire, firing{rule: rule, torrent: t})
+			}
+		}
+
+		// Забываем удаленные торренты
+		if full {
+			for id := range states {
+				if !present[id] {
+					delete(states, id)
+				}
+			}
+			e.primed[rule.ID] = true
+		}
+		for _, id := range removed {
+			delete(states, id)
+		}
+	}
+
+	// Забываем состояние удаленных и выключенных правил
+	for id := range e.states {
+		if !active[id] {
+			delete(e.states, id)
+			delete(e.primed, id)
+		}
+	}
+	if full {
+		e.pruneRegexps(rules)
+	}
+	e.mu.Unlock()
+
+	for _, f := range toFire {
+		e.fire(ctx, service, f.rule, f.torrent)
+	}
+}
+
+// Preview возвращает торренты, которые сейчас удовлетворяют условиям правила, не выполняя действий.
+// Используется снимок поллера; сервер запрашивается, только пока снимок не загружен.
+func (e *RuleEngine) Preview(ctx context.Context, service *TorrentService, rule domain.AutomationRule) ([]domain.RuleMatch, error) {
+	if err := ValidateAutomationRule(rule); err != nil {
+		return nil, err
+	}
+
+	torrents, ok := service.loadedSnapshot()
+	if !ok {
+		var err error
+		if torrents, err = service.repo.GetAll(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := time.Now()
+	matches := []domain.RuleMatch{}
+	for _, t := range torrents {
+		if e.matches(rule, t, now) {
+			matches = append(matches, domain.RuleMatch{
+				TorrentID:   t.ID,
+				TorrentName: t.Name,
+				Actions:     rule.Actions,
+			})
+		}
+	}
+	return matches, nil
+}
+
+// fire выполняет действия правила (или только записывает их в режиме dry-run)
+func (e *RuleEngine) fire(ctx context.Context, service *TorrentService, rule domain.AutomationRule, t domain.Torrent) {
+	for _, action := range rule.Actions {
+		var err error
+		if !rule.DryRun {
+			err = service.applyRuleAction(ctx, action, t)
+		}
+		e.record(domain.RuleLogEntry{
+			Time:        time.Now().Unix(),
+			RuleID:      rule.ID,
+			RuleName:    rule.Name,
+			TorrentID:   t.ID,
+			TorrentName: t.Name,
+			Action:      action.Type,
+			DryRun:      rule.DryRun,
+			Success:     err == nil,
+			Error:       errorText(err),
+		})
+
+		// После удаления торрента остальные действия не имеют смысла
+		if err != nil || action.Type == domain.RuleActionRemove || action.Type == domain.RuleActionRemoveWithData {
+			return
+		}
+	}
+}
+
+// record добавляет запись в журнал и сообщает о ней фронтенду
+func (e *RuleEngine) record(entry domain.RuleLogEntry) {
+	e.logMu.Lock()
+	e.log = append(e.log, entry)
+	if len(e.log) > maxRuleLogEntries {
+		e.log = slices.Delete(e.log, 0, len(e.log)-maxRuleLogEntries)
+	}
+	e.logMu.Unlock()
+
+	if e.emit != nil {
+		e.emit(EventRuleAction, entry)
+	}
+}
+
+// matches проверяет, удовлетворяет ли торрент всем условиям правила. Вызывается под e.mu
+func (e *RuleEngine) matches(rule domain.AutomationRule, t domain.Torrent, now time.Time) bool {
+	for _, condition := range rule.Conditions {
+		if !e.conditionMatches(condition, t, now) {
+			return false
+		}
+	}
+	return len(rule.Conditions) > 0
+}
+
+// conditionMatches проверяет одно условие
+func (e *RuleEngine) conditionMatches(c domain.RuleCondition, t domain.Torrent, now time.Time) bool {
+	switch c.Type {
+	case domain.ConditionStatus:
+		return compareText(c.Operator, string(t.Status), c.Text)
+	case domain.ConditionProgress:
+		return compareNumber(c.Operator, t.Progress, c.Number)
+	case domain.ConditionRatio:
+		return compareNumber(c.Operator, t.UploadRatio, c.Number)
+	case domain.ConditionSize:
+		return compareNumber(c.Operator, float64(t.Size), c.Number)
+	case domain.ConditionSendingPeers:
+		return compareNumber(c.Operator, float64(t.PeersSendingToUs), c.Number)
+	case domain.ConditionAge:
+		if t.AddedDate == 0 {
+			return false
+		}
+		return compareNumber(c.Operator, now.Sub(time.Unix(t.AddedDate, 0)).Hours(), c.Number)
+	case domain.ConditionStalled:
+		switch c.Operator {
+		case domain.OperatorEquals:
+			return t.IsStalled
+		case domain.OperatorNotEquals:
+			return !t.IsStalled
+		default:
+			return false
+		}
+	case domain.ConditionTracker:
+		return hasAnyTracker(t, []string{c.Text}) == isPositiveOperator(c.Operator)
+	case domain.ConditionLabel:
+		return hasAnyLabel(t, []string{c.Text}) == isPositiveOperator(c.Operator)
+	case domain.ConditionName:
+		if c.Operator == domain.OperatorMatches {
+			re := e.regexp(c.Text)
+			return re != nil && re.MatchString(t.Name)
+		}
+		return compareText(c.Operator, t.Name, c.Text)
+	default:
+		return false
+	}
+}
+
+// regexp возвращает скомпилированное выражение из кэша. Вызывается под e.mu
+func (e *RuleEngine) regexp(pattern string) *regexp.Regexp {
+	if re, ok := e.regexps[pattern]; ok {
+		return re
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		re = nil
+	}
+	e.regexps[pattern] = re
+	return re
+}
+
+// pruneRegexps оставляет в кэше только выражения текущих правил, чтобы измененные
+// и удаленные правила, а также предварительные просмотры не накапливались. Вызывается под e.mu
+func (e *RuleEngine) pruneRegexps(rules []domain.AutomationRule) {
+	used := make(map[string]bool)
+	for _, rule := range rules {
+		for _, c := range rule.Conditions {
+			if c.Type == domain.ConditionName && c.Operator == domain.OperatorMatches {
+				used[c.Text] = true
+			}
+		}
+	}
+	maps.DeleteFunc(e.regexps, func(pattern string, _ *regexp.Regexp) bool {
+		return !used[pattern]
+	})
+}
+
+// isPositiveOperator проверяет, требует ли оператор наличия значения (eq, contains),
+// а не его отсутствия (neq, notContains)
+func isPositiveOperator(op domain.RuleOperator) bool {
+	return op != domain.OperatorNotEquals && op != domain.OperatorNotContains
+}
+
+// compareNumber сравнивает числовое значение условия
+func compareNumber(op domain.RuleOperator, value float64, target float64) bool {
+	switch op {
+	case domain.OperatorEquals:
+		return value == target
+	case domain.OperatorNotEquals:
+		return value != target
+	case domain.OperatorGreater:
+		return value > target
+	case domain.OperatorGreaterEqual:
+		return value >= target
+	case domain.OperatorLess:
+		return value < target
+	case domain.OperatorLessEqual:
+		return value <= target
+	default:
+		return false
+	}
+}
+
+// compareText сравнивает текстовое значение условия без учета регистра
+func compareText(op domain.RuleOperator, value string, target string) bool {
+	switch op {
+	case domain.OperatorEquals:
+		return strings.EqualFold(value, target)
+	case domain.OperatorNotEquals:
+		return !strings.EqualFold(value, target)
+	case domain.OperatorContains:
+		return strings.Contains(strings.ToLower(value), strings.ToLower(target))
+	case domain.OperatorNotContains:
+		return !strings.Contains(strings.ToLower(value), strings.ToLower(target))
+	default:
+		return false
+	}
+}
+
+// errorText возвращает текст ошибки или пустую строку
+func errorText(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// ValidateAutomationRule проверяет, что правило можно выполнить
+func ValidateAutomationRule(rule domain.AutomationRule) error {
+	if rule.ID == "" {
+		return errors.New(ErrEmptyRuleID)
+	}
+	if len(rule.Conditions) == 0 {
+		return errors.New(ErrRuleWithoutCondition)
+	}
+	if len(rule.Actions) == 0 {
+		return errors.New(ErrRuleWithoutAction)
+	}
+
+	for _, c := range rule.Conditions {
+		if err := validateRuleCondition(c); err != nil {
+			return err
+		}
+	}
+	for _, a := range rule.Actions {
+		if err := validateRuleAction(a); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Операторы, допустимые для условий каждого вида
+var (
+	numberOperators = []domain.RuleOperator{
+		domain.OperatorEquals, domain.OperatorNotEquals,
+		domain.OperatorGreater, domain.OperatorGreaterEqual,
+		domain.OperatorLess, domain.OperatorLessEqual,
+	}
+	textOperators = []domain.RuleOperator{
+		domain.OperatorEquals, domain.OperatorNotEquals,
+		domain.OperatorContains, domain.OperatorNotContains,
+	}
+	nameOperators    = append(slices.Clone(textOperators), domain.OperatorMatches)
+	stalledOperators = []domain.RuleOperator{domain.OperatorEquals, domain.OperatorNotEquals}
+)
+
+// validateRuleCondition проверяет тип и оператор условия
+func validateRuleCondition(c domain.RuleCondition) error {
+	var operators []domain.RuleOperator
+	switch c.Type {
+	case domain.ConditionProgress, domain.ConditionRatio, domain.ConditionSize,
+		domain.ConditionSendingPeers, domain.ConditionAge:
+		operators = numberOperators
+	case domain.ConditionStatus, domain.ConditionTracker, domain.ConditionLabel:
+		operators = textOperators
+	case domain.ConditionName:
+		operators = nameOperators
+	case domain.ConditionStalled:
+		operators = stalledOperators
+	default:
+		return errors.New(ErrUnknownCondition)
+	}
+
+	if !slices.Contains(operators, c.Operator) {
+		return errors.New(ErrUnknownOperator)
+	}
+	if c.Operator == domain.OperatorMatches {
+		if _, err := regexp.Compile(c.Text); err != nil {
+			return fmt.Errorf("invalid name pattern: %w", err)
+		}
+	}
+	return nil
+}
+
+// validateRuleAction проверяет тип и параметры действия
+func validateRuleAction(a domain.RuleAction) error {
+	switch a.Type {
+	case domain.RuleActionStart, domain.RuleActionStop, domain.RuleActionRemove,
+		domain.RuleActionRemoveWithData, domain.RuleActionVerify,
+		domain.RuleActionSlowMode, domain.RuleActionNormalMode, domain.RuleActionSetLabels:
+		return nil
+	case domain.RuleActionMove:
+		if strings.TrimSpace(a.Location) == "" {
+			return errors.New(ErrEmptyMoveLocation)
+		}
+		return nil
+	default:
+		return errors.New(ErrUnknownRuleAction)
+	}
+}
+
+// AutomationRules возвращает правила автоматизации, переданные сервису последним UpdateConfig
+func (s *TorrentService) AutomationRules() []domain.AutomationRule {
+	s.rulesMu.RLock()
+	defer s.rulesMu.RUnlock()
+
+	return s.automationRules
+}
+
+// automationRulesFromConfig копирует правила автоматизации из конфигурации
+func automationRulesFromConfig(config *domain.Config) []domain.AutomationRule {
+	if config == nil {
+		return nil
+	}
+
+	rules := make([]domain.AutomationRule, 0, len(config.AutomationRules))
+	for _, rule := range config.AutomationRules {
+		rule.Conditions = slices.Clone(rule.Conditions)
+		rule.Actions = slices.Clone(rule.Actions)
+		for i := range rule.Actions {
+			rule.Actions[i].Labels = slices.Clone(rule.Actions[i].Labels)
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// applyRuleAction выполняет действие правила через методы репозитория
+func (s *TorrentService) applyRuleAction(ctx context.Context, action domain.RuleAction, t domain.Torrent) error {
+	ids := []int64{t.ID}
+	switch action.Type {
+	case domain.RuleActionStart:
+		return s.repo.Start(ctx, ids)
+	case domain.RuleActionStop:
+		return s.repo.Stop(ctx, ids)
+	case domain.RuleActionRemove:
+		return s.repo.Remove(ctx, t.ID, false)
+	case domain.RuleActionRemoveWithData:
+		return s.repo.Remove(ctx, t.ID, true)
+	case domain.RuleActionVerify:
+		return s.repo.VerifyTorrent(ctx, t.ID)
+	case domain.RuleActionSlowMode:
+		return s.SetTorrentSpeedLimit(ctx, ids, true)
+	case domain.RuleActionNormalMode:
+		return s.SetTorrentSpeedLimit(ctx, ids, false)
+	case domain.RuleActionSetLabels:
+		return s.repo.SetLabels(ctx, ids, action.Labels)
+	case domain.RuleActionMove:
+		return s.moveTorrents(ctx, ids, action.Location, true)
+	default:
+		return errors.New(ErrUnknownRuleAction)
+	}
+}
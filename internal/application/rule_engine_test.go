@@ -0,0 +1,203 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+	"transmission-client-go/internal/domain"
+)
+
+func TestRuleEngineConditionMatches(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	torrent := domain.Torrent{
+		Name:        "Ubuntu 24.04 Desktop",
+		Status:      domain.StatusSeeding,
+		Progress:    100,
+		UploadRatio: 1.5,
+		IsStalled:   true,
+		Labels:      []string{"Linux"},
+		AddedDate:   now.Add(-48 * time.Hour).Unix(),
+		Trackers:    []domain.TrackerAnnounce{{Announce: "https://torrent.ubuntu.com/announce"}},
+	}
+
+	tests := []struct {
+		name      string
+		condition domain.RuleCondition
+		want      bool
+	}{
+		{"status equals ignores case", domain.RuleCondition{Type: domain.ConditionStatus, Operator: domain.OperatorEquals, Text: "SEEDING"}, true},
+		{"ratio greater", domain.RuleCondition{Type: domain.ConditionRatio, Operator: domain.OperatorGreater, Number: 1}, true},
+		{"progress less", domain.RuleCondition{Type: domain.ConditionProgress, Operator: domain.OperatorLess, Number: 100}, false},
+		{"age in hours", domain.RuleCondition{Type: domain.ConditionAge, Operator: domain.OperatorGreaterEqual, Number: 48}, true},
+		{"name matches regexp", domain.RuleCondition{Type: domain.ConditionName, Operator: domain.OperatorMatches, Text: `^Ubuntu \d+`}, true},
+		{"name regexp does not match", domain.RuleCondition{Type: domain.ConditionName, Operator: domain.OperatorMatches, Text: `^Debian`}, false},
+		{"name contains", domain.RuleCondition{Type: domain.ConditionName, Operator: domain.OperatorContains, Text: "desktop"}, true},
+		{"name not equals", domain.RuleCondition{Type: domain.ConditionName, Operator: domain.OperatorNotEquals, Text: "Ubuntu 24.04 Desktop"}, false},
+		{"name without operator", domain.RuleCondition{Type: domain.ConditionName, Text: "Ubuntu"}, false},
+		{"stalled equals", domain.RuleCondition{Type: domain.ConditionStalled, Operator: domain.OperatorEquals}, true},
+		{"stalled not equals", domain.RuleCondition{Type: domain.ConditionStalled, Operator: domain.OperatorNotEquals}, false},
+		{"stalled without operator", domain.RuleCondition{Type: domain.ConditionStalled}, false},
+		{"tracker contains host", domain.RuleCondition{Type: domain.ConditionTracker, Operator: domain.OperatorContains, Text: "ubuntu.com"}, true},
+		{"tracker not equals", domain.RuleCondition{Type: domain.ConditionTracker, Operator: domain.OperatorNotEquals, Text: "ubuntu.com"}, false},
+		{"label equals ignores case", domain.RuleCondition{Type: domain.ConditionLabel, Operator: domain.OperatorEquals, Text: "linux"}, true},
+		{"label not contains", domain.RuleCondition{Type: domain.ConditionLabel, Operator: domain.OperatorNotContains, Text: "movies"}, true},
+	}
+
+	e := NewRuleEngine(nil, time.Minute)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := e.conditionMatches(tt.condition, torrent, now); got != tt.want {
+				t.Errorf("conditionMatches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateRuleCondition(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition domain.RuleCondition
+		wantErr   bool
+	}{
+		{"number operator", domain.RuleCondition{Type: domain.ConditionSize, Operator: domain.OperatorLessEqual}, false},
+		{"text operator on number", domain.RuleCondition{Type: domain.ConditionSize, Operator: domain.OperatorContains}, true},
+		{"matches on name", domain.RuleCondition{Type: domain.ConditionName, Operator: domain.OperatorMatches, Text: `\d+`}, false},
+		{"invalid name regexp", domain.RuleCondition{Type: domain.ConditionName, Operator: domain.OperatorMatches, Text: `(`}, true},
+		{"plain text name is not a regexp", domain.RuleCondition{Type: domain.ConditionName, Operator: domain.OperatorContains, Text: `(`}, false},
+		{"matches on label", domain.RuleCondition{Type: domain.ConditionLabel, Operator: domain.OperatorMatches, Text: "x"}, true},
+		{"stalled equals", domain.RuleCondition{Type: domain.ConditionStalled, Operator: domain.OperatorEquals}, false},
+		{"stalled without operator", domain.RuleCondition{Type: domain.ConditionStalled}, true},
+		{"stalled greater", domain.RuleCondition{Type: domain.ConditionStalled, Operator: domain.OperatorGreater}, true},
+		{"unknown condition", domain.RuleCondition{Type: "peers", Operator: domain.OperatorEquals}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRuleCondition(tt.condition)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateRuleCondition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRuleEngineTransitions(t *testing.T) {
+	rule := domain.AutomationRule{
+		ID:      "done",
+		Enabled: true,
+		DryRun:  true,
+		Conditions: []domain.RuleCondition{
+			{Type: domain.ConditionProgress, Operator: domain.OperatorEquals, Number: 100},
+		},
+		Actions: []domain.RuleAction{{Type: domain.RuleActionStop}},
+	}
+	service := &TorrentService{automationRules: []domain.AutomationRule{rule}}
+	ctx := context.Background()
+
+	e := NewRuleEngine(nil, time.Minute)
+	e.SetSource(ctx, service)
+
+	fired := func() []int64 {
+		var ids []int64
+		for _, entry := range e.Log() {
+			ids = append(ids, entry.TorrentID)
+		}
+		e.ClearLog()
+		return ids
+	}
+
+	// До первого полного снимка изменения от поллера не обрабатываются
+	e.evaluate(ctx, service, []domain.Torrent{{ID: 1, Progress: 100}}, nil, false)
+	if ids := fired(); len(ids) != 0 {
+		t.Fatalf("fired before the first snapshot: %v", ids)
+	}
+
+	// Торренты, уже удовлетворяющие условиям в первом снимке, не считаются перешедшими
+	e.evaluate(ctx, service, []domain.Torrent{{ID: 1, Progress: 100}, {ID: 2, Progress: 50}}, nil, true)
+	if ids := fired(); len(ids) != 0 {
+		t.Fatalf("fired on the first snapshot: %v", ids)
+	}
+
+	// Переход из изменений поллера срабатывает один раз
+	e.evaluate(ctx, service, []domain.Torrent{{ID: 2, Progress: 100}}, nil, false)
+	if ids := fired(); len(ids) != 1 || ids[0] != 2 {
+		t.Fatalf("fired = %v, want [2]", ids)
+	}
+	e.evaluate(ctx, service, []domain.Torrent{{ID: 1, Progress: 100}, {ID: 2, Progress: 100}}, nil, true)
+	if ids := fired(); len(ids) != 0 {
+		t.Fatalf("fired again: %v", ids)
+	}
+
+	// Удаленный торрент забывается, и повторное появление считается новым переходом
+	e.evaluate(ctx, service, nil, []int64{2}, false)
+	e.evaluate(ctx, service, []domain.Torrent{{ID: 2, Progress: 100}}, nil, false)
+	if ids := fired(); len(ids) != 1 || ids[0] != 2 {
+		t.Fatalf("fired after re-adding = %v, want [2]", ids)
+	}
+}
+
+func TestRuleEnginePreviewUsesSnapshot(t *testing.T) {
+	repo := &fakeRepo{torrents: []domain.Torrent{{ID: 1, Name: "Ubuntu"}, {ID: 2, Name: "Debian"}}}
+	service := NewTorrentService(repo)
+	ctx := context.Background()
+	rule := domain.AutomationRule{
+		ID:         "preview",
+		Conditions: []domain.RuleCondition{{Type: domain.ConditionName, Operator: domain.OperatorMatches, Text: "^Ubu"}},
+		Actions:    []domain.RuleAction{{Type: domain.RuleActionStop}},
+	}
+
+	e := NewRuleEngine(nil, time.Minute)
+	e.SetSource(ctx, service)
+
+	// До первой загрузки снимка просмотр запрашивает сервер
+	matches, err := e.Preview(ctx, service, rule)
+	if err != nil || len(matches) != 1 || matches[0].TorrentID != 1 {
+		t.Fatalf("Preview() = %+v, %v", matches, err)
+	}
+	if repo.fullCalls != 1 {
+		t.Fatalf("GetAll calls = %d, want 1", repo.fullCalls)
+	}
+
+	if _, err := service.GetTorrentUpdates(ctx); err != nil {
+		t.Fatal(err)
+	}
+	repo.fullCalls = 0
+	if _, err := e.Preview(ctx, service, rule); err != nil {
+		t.Fatal(err)
+	}
+	if repo.fullCalls != 0 {
+		t.Errorf("GetAll calls = %d, want the snapshot to be used", repo.fullCalls)
+	}
+}
+
+func TestRuleEnginePrunesRegexps(t *testing.T) {
+	saved := domain.AutomationRule{
+		ID:         "saved",
+		Enabled:    true,
+		DryRun:     true,
+		Conditions: []domain.RuleCondition{{Type: domain.ConditionName, Operator: domain.OperatorMatches, Text: "^Debian"}},
+		Actions:    []domain.RuleAction{{Type: domain.RuleActionStop}},
+	}
+	service := &TorrentService{automationRules: []domain.AutomationRule{saved}}
+	ctx := context.Background()
+
+	e := NewRuleEngine(nil, time.Minute)
+	e.SetSource(ctx, service)
+	torrents := []domain.Torrent{{ID: 1, Name: "Ubuntu"}}
+
+	// Выражения из просмотра и прежней версии правила попадают в кэш
+	e.mu.Lock()
+	e.regexp("^Ubu")
+	e.regexp("^Deb")
+	e.mu.Unlock()
+
+	e.evaluate(ctx, service, torrents, nil, false)
+	if len(e.regexps) != 2 {
+		t.Fatalf("regexps = %v, partial passes keep the cache", e.regexps)
+	}
+
+	e.evaluate(ctx, service, torrents, nil, true)
+	if _, ok := e.regexps["^Debian"]; !ok || len(e.regexps) != 1 {
+		t.Errorf("regexps = %v, want only the saved rule's pattern", e.regexps)
+	}
+}
@@ -0,0 +1,119 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+	"transmission-client-go/internal/domain"
+)
+
+// pendingMoveTimeout время, после которого перенос больше не отслеживается.
+// Transmission не сообщает об ошибке переноса отдельно, и без ограничения
+// неудачный перенос показывался бы со статусом "moving" бесконечно.
+const pendingMoveTimeout = 30 * time.Minute
+
+// pendingMoves хранит торренты, данные которых переносятся демоном.
+// Transmission обновляет downloadDir только после завершения переноса,
+// поэтому до этого момента торрент показывается со статусом "moving".
+type pendingMoves struct {
+	mu      sync.Mutex
+	targets map[int64]pendingMove
+}
+
+// pendingMove целевая директория переноса и время его начала
+type pendingMove struct {
+	location string
+	started  time.Time
+}
+
+func newPendingMoves() *pendingMoves {
+	return &pendingMoves{targets: make(map[int64]pendingMove)}
+}
+
+// add запоминает новое расположение торрентов
+func (m *pendingMoves) add(ids []int64, location string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	move := pendingMove{location: filepath.Clean(location), started: time.Now()}
+	for _, id := range ids {
+		m.targets[id] = move
+	}
+}
+
+// apply проставляет статус перемещения и забывает торренты, перенос которых
+// завершен или отслеживается дольше pendingMoveTimeout
+func (m *pendingMoves) apply(torrents []domain.Torrent) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.targets) == 0 {
+		return
+	}
+
+	for i := range torrents {
+		move, ok := m.targets[torrents[i].ID]
+		if !ok {
+			continue
+		}
+		if filepath.Clean(torrents[i].DownloadDir) == move.location || time.Since(move.started) > pendingMoveTimeout {
+			delete(m.targets, torrents[i].ID)
+			continue
+		}
+		torrents[i].Status = domain.StatusMoving
+	}
+}
+
+// has проверяет, переносятся ли сейчас данные торрента
+func (m *pendingMoves) has(id int64) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, ok := m.targets[id]
+	return ok
+}
+
+// forget удаляет торренты из списка перемещаемых
+func (m *pendingMoves) forget(ids []int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, id := range ids {
+		delete(m.targets, id)
+	}
+}
+
+// MoveTorrents переносит данные торрентов в новую директорию (move = true)
+// или указывает директорию, где данные уже лежат (move = false).
+// Ход переноса отражается статусом "moving" при последующих опросах.
+func (s *TorrentService) MoveTorrents(ctx context.Context, ids []int64, newLocation string, move bool) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if err := s.moveTorrents(ctx, ids, newLocation, move); err != nil {
+		return err
+	}
+
+	// Новое расположение попадает в историю путей, как и при добавлении торрента
+	_ = s.SaveDownloadPath(newLocation)
+	return nil
+}
+
+// moveTorrents проверяет директорию, отправляет запрос на перенос и отмечает
+// торренты как перемещаемые. Клиент отправляет путь демону как есть,
+// поэтому это единственная проверка и для ручного переноса, и для правил.
+func (s *TorrentService) moveTorrents(ctx context.Context, ids []int64, newLocation string, move bool) error {
+	if err := s.ValidateDownloadPath(ctx, newLocation); err != nil {
+		return fmt.Errorf("invalid location: %w", err)
+	}
+
+	s.moves.add(ids, newLocation)
+	if err := s.repo.MoveTorrents(ctx, ids, newLocation, move); err != nil {
+		s.moves.forget(ids)
+		return err
+	}
+	return nil
+}
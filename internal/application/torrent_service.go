@@ -1,10 +1,12 @@
 package application
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"path/filepath"
 	"slices"
+	"sync"
 	"transmission-client-go/internal/domain"
 	"transmission-client-go/internal/infrastructure"
 	"transmission-client-go/internal/infrastructure/transmission"
@@ -16,46 +18,86 @@ const (
 )
 
 type TorrentService struct {
-	repo   domain.TorrentRepository
-	config *domain.Config
+	repo     domain.TorrentRepository
+	config   *domain.Config
+	snapshot *TorrentSnapshot
+	moves    *pendingMoves
+
+	// Копия правил для фоновых движков: config изменяется без блокировок,
+	// поэтому движки читают только эту копию
+	rulesMu         sync.RWMutex
+	policyRules     []domain.PolicyRule
+	automationRules []domain.AutomationRule
 }
 
 func NewTorrentService(repo domain.TorrentRepository) *TorrentService {
 	return &TorrentService{
-		repo: repo,
+		repo:     repo,
+		snapshot: NewTorrentSnapshot(repo, DefaultResyncInterval),
+		moves:    newPendingMoves(),
 	}
 }
 
 // UpdateConfig обновляет конфигурацию сервиса
 func (s *TorrentService) UpdateConfig(config *domain.Config) {
 	s.config = config
+
+	policyRules := policyRulesFromConfig(config)
+	automationRules := automationRulesFromConfig(config)
+	s.rulesMu.Lock()
+	s.policyRules = policyRules
+	s.automationRules = automationRules
+	s.rulesMu.Unlock()
 }
 
-func (s *TorrentService) GetAllTorrents() ([]domain.Torrent, error) {
-	torrents, err := s.repo.GetAll()
+func (s *TorrentService) GetAllTorrents(ctx context.Context) ([]domain.Torrent, error) {
+	torrents, err := s.repo.GetAll(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	// Проверяем каждый торрент на превышение максимального рейтинга
-	if s.config != nil && s.config.MaxUploadRatio > 0 {
-		var torrentsToStop []int64
-		for _, t := range torrents {
-			if t.Status == domain.StatusSeeding && t.UploadRatio >= s.config.MaxUploadRatio {
-				torrentsToStop = append(torrentsToStop, t.ID)
-			}
-		}
-		// Если есть торренты для остановки, останавливаем их
-		if len(torrentsToStop) > 0 {
-			_ = s.repo.Stop(torrentsToStop)
-		}
+	s.moves.apply(torrents)
+	return torrents, nil
+}
+
+// GetTorrentUpdates обновляет снимок торрентов и возвращает изменения с прошлого вызова.
+// Полный список запрашивается только при первом вызове и периодической ресинхронизации.
+// Снимок хранит один курсор изменений, поэтому вызывать метод должен только Poller;
+// остальные потребители получают изменения через события поллера.
+func (s *TorrentService) GetTorrentUpdates(ctx context.Context) (*domain.TorrentDiff, error) {
+	diff, err := s.snapshot.Refresh(ctx)
+	if err != nil {
+		return nil, err
 	}
+	s.moves.forget(diff.Removed)
+	s.moves.apply(diff.Added)
+	s.moves.apply(diff.Changed)
+	return diff, nil
+}
 
-	return torrents, nil
+// InvalidateSnapshot требует полной синхронизации при следующем опросе
+func (s *TorrentService) InvalidateSnapshot() {
+	s.snapshot.Invalidate()
+}
+
+// SnapshotTorrents возвращает список торрентов из последнего снимка
+func (s *TorrentService) SnapshotTorrents() []domain.Torrent {
+	torrents := s.snapshot.Torrents()
+	s.moves.apply(torrents)
+	return torrents
+}
+
+// loadedSnapshot возвращает снимок поллера для фоновых движков;
+// второй результат false, пока снимок еще не загружен
+func (s *TorrentService) loadedSnapshot() ([]domain.Torrent, bool) {
+	if !s.snapshot.Loaded() {
+		return nil, false
+	}
+	return s.SnapshotTorrents(), true
 }
 
 // GetDefaultDownloadDir возвращает директорию загрузки по умолчанию
-func (s *TorrentService) GetDefaultDownloadDir() (string, error) {
+func (s *TorrentService) GetDefaultDownloadDir(ctx context.Context) (string, error) {
 	// Проверяем, есть ли сохраненный путь в конфигурации
 	if s.config != nil && s.config.DefaultDownloadPath != "" {
 		return s.config.DefaultDownloadPath, nil
@@ -67,7 +109,7 @@ func (s *TorrentService) GetDefaultDownloadDir() (string, error) {
 		return "", fmt.Errorf("repository does not support getting default download directory")
 	}
 
-	path, err := client.GetDefaultDownloadDir()
+	path, err := client.GetDefaultDownloadDir(ctx)
 	if err != nil {
 		return "", err
 	}
@@ -116,9 +158,9 @@ func (s *TorrentService) SaveDownloadPath(path string) error {
 }
 
 // fetchDefaultPathIfEmpty пытается получить путь по умолчанию, если он не установлен
-func (s *TorrentService) fetchDefaultPathIfEmpty() string {
+func (s *TorrentService) fetchDefaultPathIfEmpty(ctx context.Context) string {
 	if s.config.DefaultDownloadPath == "" {
-		defaultPath, err := s.GetDefaultDownloadDir()
+		defaultPath, err := s.GetDefaultDownloadDir(ctx)
 		if err == nil && defaultPath != "" {
 			return defaultPath
 		}
@@ -127,13 +169,13 @@ func (s *TorrentService) fetchDefaultPathIfEmpty() string {
 }
 
 // fetchPathFromClient пытается получить путь напрямую из клиента Transmission
-func (s *TorrentService) fetchPathFromClient() string {
+func (s *TorrentService) fetchPathFromClient(ctx context.Context) string {
 	client, ok := s.repo.(*transmission.TransmissionClient)
 	if !ok {
 		return ""
 	}
 
-	path, err := client.GetDefaultDownloadDir()
+	path, err := client.GetDefaultDownloadDir(ctx)
 	if err != nil || path == "" {
 		return ""
 	}
@@ -162,7 +204,7 @@ func (s *TorrentService) addUniquePathsFromHistory(result []string) []string {
 }
 
 // GetDownloadPaths возвращает список сохраненных путей загрузки
-func (s *TorrentService) GetDownloadPaths() ([]string, error) {
+func (s *TorrentService) GetDownloadPaths(ctx context.Context) ([]string, error) {
 	if s.config == nil {
 		return nil, errors.New(ErrConfigNotInited)
 	}
@@ -171,7 +213,7 @@ func (s *TorrentService) GetDownloadPaths() ([]string, error) {
 	var result []string
 
 	// Добавляем путь по умолчанию, если он есть
-	defaultPath := s.fetchDefaultPathIfEmpty()
+	defaultPath := s.fetchDefaultPathIfEmpty(ctx)
 	if defaultPath != "" {
 		result = append(result, defaultPath)
 	}
@@ -182,7 +224,7 @@ func (s *TorrentService) GetDownloadPaths() ([]string, error) {
 	// Если после всех попыток список путей всё ещё пуст,
 	// пытаемся получить путь напрямую из клиента
 	if len(result) == 0 {
-		path := s.fetchPathFromClient()
+		path := s.fetchPathFromClient(ctx)
 		if path != "" {
 			result = append(result, path)
 		}
@@ -191,67 +233,112 @@ func (s *TorrentService) GetDownloadPaths() ([]string, error) {
 	return result, nil
 }
 
-func (s *TorrentService) AddTorrent(url string, downloadDir string) error {
+func (s *TorrentService) AddTorrent(ctx context.Context, url string, options domain.AddTorrentOptions) (*domain.AddTorrentResult, error) {
 	// Проверяем путь перед добавлением торрента
-	if err := s.ValidateDownloadPath(downloadDir); err != nil {
-		return fmt.Errorf("invalid download path: %w", err)
+	if err := s.ValidateDownloadPath(ctx, options.DownloadDir); err != nil {
+		return nil, fmt.Errorf("invalid download path: %w", err)
 	}
 
 	// Если указана директория загрузки, сохраняем ее в историю
-	if downloadDir != "" {
-		_ = s.SaveDownloadPath(downloadDir)
+	if options.DownloadDir != "" {
+		_ = s.SaveDownloadPath(options.DownloadDir)
 	}
 
 	client, ok := s.repo.(*transmission.TransmissionClient)
 	if !ok {
-		return fmt.Errorf("repository does not support setting download directory")
+		return nil, fmt.Errorf("repository does not support setting download directory")
 	}
 
-	return client.Add(url, downloadDir)
+	return client.Add(ctx, url, options)
 }
 
-func (s *TorrentService) AddTorrentFile(filepath string, downloadDir string) error {
+func (s *TorrentService) AddTorrentFile(ctx context.Context, filepath string, options domain.AddTorrentOptions) (*domain.AddTorrentResult, error) {
 	// Проверяем путь перед добавлением торрента
-	if err := s.ValidateDownloadPath(downloadDir); err != nil {
-		return fmt.Errorf("invalid download path: %w", err)
+	if err := s.ValidateDownloadPath(ctx, options.DownloadDir); err != nil {
+		return nil, fmt.Errorf("invalid download path: %w", err)
 	}
 
 	// Если указана директория загрузки, сохраняем ее в историю
-	if downloadDir != "" {
-		_ = s.SaveDownloadPath(downloadDir)
+	if options.DownloadDir != "" {
+		_ = s.SaveDownloadPath(options.DownloadDir)
 	}
 
 	client, ok := s.repo.(*transmission.TransmissionClient)
 	if !ok {
-		return fmt.Errorf("repository does not support setting download directory")
+		return nil, fmt.Errorf("repository does not support setting download directory")
 	}
 
-	return client.AddFile(filepath, downloadDir)
+	return client.AddFile(ctx, filepath, options)
 }
 
-func (s *TorrentService) RemoveTorrent(id int64, deleteData bool) error {
-	return s.repo.Remove(id, deleteData)
+func (s *TorrentService) RemoveTorrent(ctx context.Context, id int64, deleteData bool) error {
+	return s.repo.Remove(ctx, id, deleteData)
 }
 
-func (s *TorrentService) StartTorrents(ids []int64) error {
-	return s.repo.Start(ids)
+func (s *TorrentService) StartTorrents(ctx context.Context, ids []int64) error {
+	return s.repo.Start(ctx, ids)
 }
 
-func (s *TorrentService) StopTorrents(ids []int64) error {
-	return s.repo.Stop(ids)
+func (s *TorrentService) StopTorrents(ctx context.Context, ids []int64) error {
+	return s.repo.Stop(ctx, ids)
 }
 
-func (s *TorrentService) GetSessionStats() (*domain.SessionStats, error) {
-	return s.repo.GetSessionStats()
+// StartTorrentsNow запускает торренты в обход очереди
+func (s *TorrentService) StartTorrentsNow(ctx context.Context, ids []int64) error {
+	return s.repo.StartNow(ctx, ids)
+}
+
+// QueueMoveTop перемещает торренты в начало очереди
+func (s *TorrentService) QueueMoveTop(ctx context.Context, ids []int64) error {
+	return s.repo.QueueMoveTop(ctx, ids)
+}
+
+// QueueMoveUp перемещает торренты вверх по очереди
+func (s *TorrentService) QueueMoveUp(ctx context.Context, ids []int64) error {
+	return s.repo.QueueMoveUp(ctx, ids)
+}
+
+// QueueMoveDown перемещает торренты вниз по очереди
+func (s *TorrentService) QueueMoveDown(ctx context.Context, ids []int64) error {
+	return s.repo.QueueMoveDown(ctx, ids)
+}
+
+// QueueMoveBottom перемещает торренты в конец очереди
+func (s *TorrentService) QueueMoveBottom(ctx context.Context, ids []int64) error {
+	return s.repo.QueueMoveBottom(ctx, ids)
+}
+
+// GetQueueSettings возвращает настройки очередей
+func (s *TorrentService) GetQueueSettings(ctx context.Context) (*domain.QueueSettings, error) {
+	return s.repo.GetQueueSettings(ctx)
+}
+
+// SetQueueSettings сохраняет настройки очередей
+func (s *TorrentService) SetQueueSettings(ctx context.Context, settings domain.QueueSettings) error {
+	return s.repo.SetQueueSettings(ctx, settings)
+}
+
+func (s *TorrentService) GetSessionStats(ctx context.Context) (*domain.SessionStats, error) {
+	return s.repo.GetSessionStats(ctx)
 }
 
 // Новые методы для работы с файлами
-func (s *TorrentService) GetTorrentFiles(id int64) ([]domain.TorrentFile, error) {
-	return s.repo.GetTorrentFiles(id)
+func (s *TorrentService) GetTorrentFiles(ctx context.Context, id int64) ([]domain.TorrentFile, error) {
+	return s.repo.GetTorrentFiles(ctx, id)
+}
+
+// RenameTorrentPath переименовывает файл или папку торрента и возвращает обновленный список файлов
+func (s *TorrentService) RenameTorrentPath(ctx context.Context, id int64, oldPath string, newName string) ([]domain.TorrentFile, error) {
+	return s.repo.RenameTorrentPath(ctx, id, oldPath, newName)
 }
 
-func (s *TorrentService) SetFilesWanted(id int64, fileIds []int, wanted bool) error {
-	return s.repo.SetFilesWanted(id, fileIds, wanted)
+// SetFilesPriority устанавливает приоритет загрузки файлов
+func (s *TorrentService) SetFilesPriority(ctx context.Context, id int64, fileIds []int, priority domain.FilePriority) error {
+	return s.repo.SetFilesPriority(ctx, id, fileIds, priority)
+}
+
+func (s *TorrentService) SetFilesWanted(ctx context.Context, id int64, fileIds []int, wanted bool) error {
+	return s.repo.SetFilesWanted(ctx, id, fileIds, wanted)
 }
 
 // convertSpeedToKBps конвертирует скорость из указанных единиц в КБ/с
@@ -265,7 +352,7 @@ func convertSpeedToKBps(speed int, unit string) int64 {
 }
 
 // SetTorrentSpeedLimit устанавливает ограничение скорости для указанных торрентов
-func (s *TorrentService) SetTorrentSpeedLimit(ids []int64, isSlowMode bool) error {
+func (s *TorrentService) SetTorrentSpeedLimit(ctx context.Context, ids []int64, isSlowMode bool) error {
 	var downloadLimit, uploadLimit int64
 	if isSlowMode {
 		if s.config != nil && s.config.SlowSpeedLimit > 0 {
@@ -279,7 +366,7 @@ func (s *TorrentService) SetTorrentSpeedLimit(ids []int64, isSlowMode bool) erro
 			uploadLimit = 10
 		}
 	}
-	return s.repo.SetTorrentSpeedLimit(ids, downloadLimit, uploadLimit)
+	return s.repo.SetTorrentSpeedLimit(ctx, ids, downloadLimit, uploadLimit)
 }
 
 // RemoveDownloadPath удаляет путь из истории путей скачивания
@@ -308,7 +395,7 @@ func (s *TorrentService) RemoveDownloadPath(path string) error {
 }
 
 // ValidateDownloadPath проверяет существование и доступность пути для скачивания
-func (s *TorrentService) ValidateDownloadPath(path string) error {
+func (s *TorrentService) ValidateDownloadPath(ctx context.Context, path string) error {
 	// Проверяем, что путь не пустой
 	if path == "" {
 		return fmt.Errorf("download path cannot be empty")
@@ -326,10 +413,80 @@ func (s *TorrentService) ValidateDownloadPath(path string) error {
 		return fmt.Errorf("repository does not support path validation")
 	}
 
-	return client.ValidateDownloadPath(absPath)
+	return client.ValidateDownloadPath(ctx, absPath)
 }
 
 // VerifyTorrent запускает процесс проверки целостности данных торрента
-func (s *TorrentService) VerifyTorrent(id int64) error {
-	return s.repo.VerifyTorrent(id)
+func (s *TorrentService) VerifyTorrent(ctx context.Context, id int64) error {
+	return s.repo.VerifyTorrent(ctx, id)
+}
+
+// GetTorrentTrackers возвращает трекеры торрента со статистикой анонсов
+func (s *TorrentService) GetTorrentTrackers(ctx context.Context, id int64) ([]domain.TorrentTracker, error) {
+	return s.repo.GetTorrentTrackers(ctx, id)
+}
+
+// AddTrackers добавляет трекеры к торренту
+func (s *TorrentService) AddTrackers(ctx context.Context, id int64, announceURLs []string) error {
+	return s.repo.AddTrackers(ctx, id, announceURLs)
+}
+
+// ReplaceTracker заменяет адрес анонса трекера
+func (s *TorrentService) ReplaceTracker(ctx context.Context, id int64, trackerID int64, announceURL string) error {
+	return s.repo.ReplaceTracker(ctx, id, trackerID, announceURL)
+}
+
+// RemoveTrackers удаляет трекеры торрента
+func (s *TorrentService) RemoveTrackers(ctx context.Context, id int64, trackerIDs []int64) error {
+	return s.repo.RemoveTrackers(ctx, id, trackerIDs)
+}
+
+// GetTorrentPeers возвращает подключенных пиров торрента
+func (s *TorrentService) GetTorrentPeers(ctx context.Context, id int64) (*domain.TorrentPeers, error) {
+	return s.repo.GetTorrentPeers(ctx, id)
+}
+
+// GetTorrentDetails возвращает подробные сведения о торренте
+func (s *TorrentService) GetTorrentDetails(ctx context.Context, id int64) (*domain.TorrentDetails, error) {
+	return s.repo.GetTorrentDetails(ctx, id)
+}
+
+// SetSeedingPolicy задает торрентам условия остановки раздачи, которые выполняет демон
+func (s *TorrentService) SetSeedingPolicy(ctx context.Context, ids []int64, policy domain.SeedingPolicy) error {
+	return s.repo.SetSeedingPolicy(ctx, ids, policy)
+}
+
+// GetSpeedSettings возвращает ограничения скорости сессии
+func (s *TorrentService) GetSpeedSettings(ctx context.Context) (*domain.SpeedSettings, error) {
+	return s.repo.GetSpeedSettings(ctx)
+}
+
+// SetSpeedSettings сохраняет ограничения скорости сессии
+func (s *TorrentService) SetSpeedSettings(ctx context.Context, settings domain.SpeedSettings) error {
+	return s.repo.SetSpeedSettings(ctx, settings)
+}
+
+// SetAltSpeedEnabled включает или выключает альтернативные ограничения скорости
+func (s *TorrentService) SetAltSpeedEnabled(ctx context.Context, enabled bool) error {
+	return s.repo.SetAltSpeedEnabled(ctx, enabled)
+}
+
+// GetSessionSettings возвращает настройки демона
+func (s *TorrentService) GetSessionSettings(ctx context.Context) (*domain.SessionSettings, error) {
+	return s.repo.GetSessionSettings(ctx)
+}
+
+// UpdateSessionSettings сохраняет изменившиеся настройки демона
+func (s *TorrentService) UpdateSessionSettings(ctx context.Context, settings domain.SessionSettings) error {
+	return s.repo.UpdateSessionSettings(ctx, settings)
+}
+
+// TestPort проверяет доступность порта для пиров
+func (s *TorrentService) TestPort(ctx context.Context) (bool, error) {
+	return s.repo.TestPort(ctx)
+}
+
+// UpdateBlocklist обновляет список блокировки и возвращает количество правил
+func (s *TorrentService) UpdateBlocklist(ctx context.Context) (int64, error) {
+	return s.repo.UpdateBlocklist(ctx)
 }
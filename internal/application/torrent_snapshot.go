@@ -0,0 +1,153 @@
+package application
+
+import (
+	"cmp"
+	"context"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+	"transmission-client-go/internal/domain"
+)
+
+// DefaultResyncInterval как часто снимок полностью перезапрашивается, чтобы исправить расхождения
+const DefaultResyncInterval = 5 * time.Minute
+
+// TorrentSnapshot хранит последний известный список торрентов и обновляет его
+// по списку недавно активных торрентов, выполняя полную синхронизацию периодически
+type TorrentSnapshot struct {
+	repo           domain.TorrentRepository
+	resyncInterval time.Duration
+
+	mu       sync.RWMutex
+	torrents map[int64]domain.Torrent
+	lastFull time.Time
+	stale    bool // Изменения могли быть пропущены, следующее обновление должно быть полным
+}
+
+func NewTorrentSnapshot(repo domain.TorrentRepository, resyncInterval time.Duration) *TorrentSnapshot {
+	return &TorrentSnapshot{
+		repo:           repo,
+		resyncInterval: resyncInterval,
+		torrents:       make(map[int64]domain.Torrent),
+	}
+}
+
+// Reset сбрасывает снимок, следующее обновление будет полным
+func (s *TorrentSnapshot) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.torrents = make(map[int64]domain.Torrent)
+	s.lastFull = time.Time{}
+	s.stale = false
+}
+
+// Invalidate помечает снимок устаревшим после ошибки или переподключения.
+// Недавно активные торренты покрывают только последнюю минуту, поэтому следующее
+// обновление будет полным; торренты сохраняются, чтобы удаленные за это время попали в diff.
+func (s *TorrentSnapshot) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stale = true
+}
+
+// Loaded проверяет, выполнялась ли уже полная синхронизация
+func (s *TorrentSnapshot) Loaded() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return !s.lastFull.IsZero()
+}
+
+// Torrents возвращает текущий снимок, отсортированный по ID
+func (s *TorrentSnapshot) Torrents() []domain.Torrent {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]domain.Torrent, 0, len(s.torrents))
+	for _, t := range s.torrents {
+		result = append(result, t)
+	}
+	slices.SortFunc(result, func(a, b domain.Torrent) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return result
+}
+
+// Refresh обновляет снимок и возвращает изменения с предыдущего обновления
+func (s *TorrentSnapshot) Refresh(ctx context.Context) (*domain.TorrentDiff, error) {
+	s.mu.RLock()
+	needFull := s.stale || s.lastFull.IsZero() || time.Since(s.lastFull) >= s.resyncInterval
+	s.mu.RUnlock()
+
+	if needFull {
+		return s.fullSync(ctx)
+	}
+	return s.deltaSync(ctx)
+}
+
+// fullSync запрашивает все торренты и сравнивает их с текущим снимком
+func (s *TorrentSnapshot) fullSync(ctx context.Context) (*domain.TorrentDiff, error) {
+	torrents, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	diff := &domain.TorrentDiff{Full: true}
+	fresh := make(map[int64]domain.Torrent, len(torrents))
+	for _, t := range torrents {
+		fresh[t.ID] = t
+		old, exists := s.torrents[t.ID]
+		switch {
+		case !exists:
+			diff.Added = append(diff.Added, t)
+		case !reflect.DeepEqual(old, t):
+			diff.Changed = append(diff.Changed, t)
+		}
+	}
+	for id := range s.torrents {
+		if _, exists := fresh[id]; !exists {
+			diff.Removed = append(diff.Removed, id)
+		}
+	}
+
+	s.torrents = fresh
+	s.lastFull = time.Now()
+	s.stale = false
+	return diff, nil
+}
+
+// deltaSync запрашивает только недавно изменившиеся торренты
+func (s *TorrentSnapshot) deltaSync(ctx context.Context) (*domain.TorrentDiff, error) {
+	changed, removed, err := s.repo.GetRecentlyActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	diff := &domain.TorrentDiff{}
+	for _, t := range changed {
+		old, exists := s.torrents[t.ID]
+		switch {
+		case !exists:
+			diff.Added = append(diff.Added, t)
+		case !reflect.DeepEqual(old, t):
+			diff.Changed = append(diff.Changed, t)
+		}
+		s.torrents[t.ID] = t
+	}
+	for _, id := range removed {
+		if _, exists := s.torrents[id]; exists {
+			delete(s.torrents, id)
+			diff.Removed = append(diff.Removed, id)
+		}
+	}
+	return diff, nil
+}
@@ -0,0 +1,176 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"transmission-client-go/internal/domain"
+	"transmission-client-go/internal/infrastructure/transmission"
+)
+
+const (
+	ErrEmptyTrackerPattern  = "tracker pattern cannot be empty"
+	ErrUnknownTrackerMatch  = "unknown tracker match mode"
+	trackerRewriteBatchSize = 10 // Сколько торрентов обновляется параллельно в одной пачке
+)
+
+// trackerRewriter вычисляет новый адрес трекера; второй результат false, если адрес не подходит
+type trackerRewriter func(announce string) (string, bool)
+
+// newTrackerRewriter создает функцию замены для указанного режима сопоставления
+func newTrackerRewriter(mode domain.TrackerMatchMode, pattern string, replacement string) (trackerRewriter, error) {
+	if pattern == "" {
+		return nil, errors.New(ErrEmptyTrackerPattern)
+	}
+
+	switch mode {
+	case domain.TrackerMatchExact:
+		return func(announce string) (string, bool) {
+			return replacement, announce == pattern
+		}, nil
+	case domain.TrackerMatchPrefix:
+		return func(announce string) (string, bool) {
+			if !strings.HasPrefix(announce, pattern) {
+				return "", false
+			}
+			return replacement + strings.TrimPrefix(announce, pattern), true
+		}, nil
+	case domain.TrackerMatchRegex:
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tracker pattern: %w", err)
+		}
+		return func(announce string) (string, bool) {
+			if !re.MatchString(announce) {
+				return "", false
+			}
+			return re.ReplaceAllString(announce, replacement), true
+		}, nil
+	default:
+		return nil, errors.New(ErrUnknownTrackerMatch)
+	}
+}
+
+// planTrackerRewrite составляет список замен по трекерам из текущего списка торрентов.
+// Новые адреса проверяются сразу, чтобы некорректные замены были видны до применения.
+func (s *TorrentService) planTrackerRewrite(ctx context.Context, mode domain.TrackerMatchMode, pattern string, replacement string) ([]domain.TrackerRewriteChange, error) {
+	rewrite, err := newTrackerRewriter(mode, pattern, replacement)
+	if err != nil {
+		return nil, err
+	}
+
+	torrents, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []domain.TrackerRewriteChange{}
+	for _, t := range torrents {
+		for _, tracker := range t.Trackers {
+			newURL, ok := rewrite(tracker.Announce)
+			if !ok || newURL == tracker.Announce {
+				continue
+			}
+			change := domain.TrackerRewriteChange{
+				TorrentID:   t.ID,
+				TorrentName: t.Name,
+				TrackerID:   tracker.ID,
+				OldURL:      tracker.Announce,
+				NewURL:      newURL,
+			}
+			if err := transmission.ValidateAnnounceURL(newURL); err != nil {
+				change.Error = err.Error()
+			}
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+// PreviewTrackerRewrite возвращает отчет о заменах без их применения
+func (s *TorrentService) PreviewTrackerRewrite(ctx context.Context, mode domain.TrackerMatchMode, pattern string, replacement string) (*domain.TrackerRewriteReport, error) {
+	changes, err := s.planTrackerRewrite(ctx, mode, pattern, replacement)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.TrackerRewriteReport{DryRun: true, Changes: changes}, nil
+}
+
+// ApplyTrackerRewrite применяет замены, показанные в предварительном просмотре, пачками
+// и возвращает результат по каждому торренту. Замены одного торрента отправляются одним запросом.
+func (s *TorrentService) ApplyTrackerRewrite(ctx context.Context, changes []domain.TrackerRewriteChange) (*domain.TrackerRewriteReport, error) {
+	// Группируем замены по торрентам, сохраняя порядок
+	var order []int64
+	byTorrent := make(map[int64][]domain.TrackerRewriteChange)
+	for _, change := range changes {
+		if _, exists := byTorrent[change.TorrentID]; !exists {
+			order = append(order, change.TorrentID)
+		}
+		byTorrent[change.TorrentID] = append(byTorrent[change.TorrentID], change)
+	}
+
+	results := make([]domain.TrackerRewriteResult, len(order))
+	for start := 0; start < len(order); start += trackerRewriteBatchSize {
+		end := min(start+trackerRewriteBatchSize, len(order))
+
+		var wg sync.WaitGroup
+		for i := start; i < end; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				results[i] = s.applyTorrentTrackerChanges(ctx, byTorrent[order[i]])
+			}(i)
+		}
+		wg.Wait()
+	}
+
+	return &domain.TrackerRewriteReport{Changes: changes, Results: results}, nil
+}
+
+// applyTorrentTrackerChanges применяет замены трекеров одного торрента.
+// План пришел от клиента, поэтому новые адреса проверяются заново, а клиент Transmission
+// отклоняет замены, если адрес трекера изменился после предварительного просмотра.
+// Некорректные замены пропускаются и отмечают торрент как неуспешный.
+func (s *TorrentService) applyTorrentTrackerChanges(ctx context.Context, changes []domain.TrackerRewriteChange) domain.TrackerRewriteResult {
+	result := domain.TrackerRewriteResult{
+		TorrentID:   changes[0].TorrentID,
+		TorrentName: changes[0].TorrentName,
+		Success:     true,
+	}
+
+	replacements := make([]domain.TrackerReplacement, 0, len(changes))
+	for _, change := range changes {
+		if err := transmission.ValidateAnnounceURL(change.NewURL); err != nil {
+			result.Success = false
+			result.Error = trackerRewriteError(err)
+			continue
+		}
+		replacements = append(replacements, domain.TrackerReplacement{
+			TrackerID: change.TrackerID,
+			OldURL:    change.OldURL,
+			NewURL:    change.NewURL,
+		})
+	}
+	if len(replacements) == 0 {
+		return result
+	}
+
+	if err := s.repo.ReplaceTrackers(ctx, result.TorrentID, replacements); err != nil {
+		result.Success = false
+		result.Error = trackerRewriteError(err)
+	}
+	return result
+}
+
+// trackerRewriteError возвращает ключ локализации для LocalizedError,
+// чтобы отчет можно было перевести, и текст ошибки в остальных случаях
+func trackerRewriteError(err error) string {
+	var locErr *transmission.LocalizedError
+	if errors.As(err, &locErr) {
+		return locErr.Error()
+	}
+	return err.Error()
+}
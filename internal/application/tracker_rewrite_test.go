@@ -0,0 +1,54 @@
+package application
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"transmission-client-go/internal/domain"
+)
+
+// trackerRepo запоминает переданные замены трекеров
+type trackerRepo struct {
+	domain.TorrentRepository
+
+	mu       sync.Mutex
+	replaced map[int64][]domain.TrackerReplacement
+}
+
+func (r *trackerRepo) ReplaceTrackers(ctx context.Context, id int64, replacements []domain.TrackerReplacement) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.replaced[id] = replacements
+	return nil
+}
+
+func TestApplyTrackerRewriteRevalidatesPlan(t *testing.T) {
+	repo := &trackerRepo{replaced: make(map[int64][]domain.TrackerReplacement)}
+	service := NewTorrentService(repo)
+
+	// План пришел от клиента: ошибка проверки не заполнена, но новый адрес некорректен
+	report, err := service.ApplyTrackerRewrite(context.Background(), []domain.TrackerRewriteChange{
+		{TorrentID: 1, TrackerID: 0, OldURL: "http://a/announce", NewURL: "https://a/announce"},
+		{TorrentID: 2, TrackerID: 3, OldURL: "http://b/announce", NewURL: "ftp://b/announce"},
+	})
+	if err != nil {
+		t.Fatalf("ApplyTrackerRewrite() error = %v", err)
+	}
+
+	want := map[int64][]domain.TrackerReplacement{
+		1: {{TrackerID: 0, OldURL: "http://a/announce", NewURL: "https://a/announce"}},
+	}
+	if !reflect.DeepEqual(repo.replaced, want) {
+		t.Errorf("replaced = %+v, want %+v", repo.replaced, want)
+	}
+
+	wantResults := []domain.TrackerRewriteResult{
+		{TorrentID: 1, Success: true},
+		{TorrentID: 2, Error: "errors.invalidTrackerURL"},
+	}
+	if !reflect.DeepEqual(report.Results, wantResults) {
+		t.Errorf("Results = %+v, want %+v", report.Results, wantResults)
+	}
+}
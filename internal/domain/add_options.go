@@ -0,0 +1,45 @@
+package domain
+
+// BandwidthPriority приоритет торрента при распределении скорости между торрентами
+type BandwidthPriority string
+
+const (
+	BandwidthPriorityLow    BandwidthPriority = "low"
+	BandwidthPriorityNormal BandwidthPriority = "normal"
+	BandwidthPriorityHigh   BandwidthPriority = "high"
+)
+
+// AddTorrentOptions параметры добавления торрента.
+// Нулевые значения оставляют настройки демона по умолчанию.
+type AddTorrentOptions struct {
+	DownloadDir       string
+	Paused            *bool             // Добавить торрент остановленным; nil — по настройке демона
+	BandwidthPriority BandwidthPriority // Пустое значение — обычный приоритет
+	PeerLimit         int64             // Максимальное число пиров, 0 — по умолчанию
+	Labels            []string
+	FilesWanted       []int64 // Индексы файлов, которые нужно загрузить
+	FilesUnwanted     []int64 // Индексы файлов, которые загружать не нужно
+	PriorityHigh      []int64 // Индексы файлов с высоким приоритетом
+	PriorityLow       []int64 // Индексы файлов с низким приоритетом
+	Cookies           string  // Cookies для загрузки .torrent по URL
+	MergeTrackers     bool    // Добавить трекеры к уже существующему торренту-дубликату
+}
+
+// AddTorrentStatus итог добавления торрента
+type AddTorrentStatus string
+
+const (
+	AddStatusAdded     AddTorrentStatus = "added"
+	AddStatusDuplicate AddTorrentStatus = "duplicate"
+)
+
+// AddTorrentResult результат добавления торрента. Для дубликата ID и Name
+// относятся к уже существующему торренту.
+type AddTorrentResult struct {
+	Status         AddTorrentStatus
+	ID             int64
+	Name           string
+	InfoHash       string
+	MergedTrackers int    // Количество трекеров, добавленных к существующему торренту
+	Message        string // Локализованное описание результата
+}
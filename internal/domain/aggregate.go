@@ -0,0 +1,20 @@
+package domain
+
+// TorrentRef адресует торрент на конкретном сервере
+type TorrentRef struct {
+	ServerID string
+	ID       int64
+}
+
+// AggregatedTorrents объединенный список торрентов со всех серверов
+type AggregatedTorrents struct {
+	Torrents []Torrent
+	Errors   map[string]string // Ошибки по ID сервера для недоступных серверов
+}
+
+// AggregatedSessionStats суммарная статистика по всем серверам
+type AggregatedSessionStats struct {
+	Total     SessionStats
+	PerServer map[string]SessionStats
+	Errors    map[string]string // Ошибки по ID сервера для недоступных серверов
+}
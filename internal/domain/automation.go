@@ -0,0 +1,94 @@
+package domain
+
+// RuleConditionType свойство торрента, которое проверяет условие правила
+type RuleConditionType string
+
+const (
+	ConditionStatus       RuleConditionType = "status"       // Text: статус торрента
+	ConditionProgress     RuleConditionType = "progress"     // Number: процент загрузки
+	ConditionRatio        RuleConditionType = "ratio"        // Number: рейтинг отдачи
+	ConditionTracker      RuleConditionType = "tracker"      // Text: часть адреса трекера
+	ConditionLabel        RuleConditionType = "label"        // Text: метка
+	ConditionName         RuleConditionType = "name"         // Text: имя или регулярное выражение для оператора matches
+	ConditionSize         RuleConditionType = "size"         // Number: размер в байтах
+	ConditionAge          RuleConditionType = "age"          // Number: часы с момента добавления
+	ConditionSendingPeers RuleConditionType = "sendingPeers" // Number: пиры, от которых сейчас идет загрузка
+	ConditionStalled      RuleConditionType = "stalled"      // Без значения: eq - торрент не получает и не отдает данные, neq - обратное
+)
+
+// RuleOperator способ сравнения значения условия
+type RuleOperator string
+
+const (
+	OperatorEquals       RuleOperator = "eq"
+	OperatorNotEquals    RuleOperator = "neq"
+	OperatorGreater      RuleOperator = "gt"
+	OperatorGreaterEqual RuleOperator = "gte"
+	OperatorLess         RuleOperator = "lt"
+	OperatorLessEqual    RuleOperator = "lte"
+	OperatorContains     RuleOperator = "contains"
+	OperatorNotContains  RuleOperator = "notContains"
+	OperatorMatches      RuleOperator = "matches" // Регулярное выражение, только для имени
+)
+
+// RuleCondition условие правила. Числовые условия используют Number, текстовые - Text
+type RuleCondition struct {
+	Type     RuleConditionType `json:"type"`
+	Operator RuleOperator      `json:"operator"`
+	Text     string            `json:"text"`
+	Number   float64           `json:"number"`
+}
+
+// RuleActionType действие правила
+type RuleActionType string
+
+const (
+	RuleActionStart          RuleActionType = "start"
+	RuleActionStop           RuleActionType = "stop"
+	RuleActionRemove         RuleActionType = "remove"
+	RuleActionRemoveWithData RuleActionType = "removeWithData"
+	RuleActionMove           RuleActionType = "move"
+	RuleActionVerify         RuleActionType = "verify"
+	RuleActionSlowMode       RuleActionType = "slowMode"
+	RuleActionNormalMode     RuleActionType = "normalMode"
+	RuleActionSetLabels      RuleActionType = "setLabels"
+)
+
+// RuleAction действие, выполняемое при срабатывании правила
+type RuleAction struct {
+	Type     RuleActionType `json:"type"`
+	Location string         `json:"location"` // Директория для move
+	Labels   []string       `json:"labels"`   // Метки для setLabels
+}
+
+// AutomationRule правило автоматизации: когда торрент начинает удовлетворять всем условиям
+// и остается в этом состоянии ForMinutes минут, выполняются действия
+type AutomationRule struct {
+	ID         string          `json:"id"`
+	Name       string          `json:"name"`
+	Enabled    bool            `json:"enabled"`
+	DryRun     bool            `json:"dryRun"` // Только записывать действия в журнал, не выполняя их
+	Conditions []RuleCondition `json:"conditions"`
+	ForMinutes int             `json:"forMinutes"`
+	Actions    []RuleAction    `json:"actions"`
+}
+
+// RuleLogEntry запись журнала о срабатывании правила автоматизации
+type RuleLogEntry struct {
+	Time        int64 // Unix-время
+	RuleID      string
+	RuleName    string
+	TorrentID   int64
+	TorrentName string
+	Action      RuleActionType
+	DryRun      bool
+	Success     bool
+	Error       string
+}
+
+// RuleMatch торрент, подходящий под условия правила, и действия, которые будут выполнены
+type RuleMatch struct {
+	TorrentID   int64
+	TorrentName string
+	Actions     []RuleAction
+}
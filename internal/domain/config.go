@@ -1,16 +1,157 @@
 package domain
 
-// Config represents the application configuration
-type Config struct {
+import "fmt"
+
+// ServerProfile описывает именованное подключение к демону Transmission
+type ServerProfile struct {
+	ID                  string   `json:"id"`
+	Name                string   `json:"name"`
 	Host                string   `json:"host"`
 	Port                int      `json:"port"`
 	Username            string   `json:"username"`
 	Password            string   `json:"password"`
-	Language            string   `json:"language"`            // Added for localization support
-	Theme               string   `json:"theme"`               // Added for theme support: "light", "dark", "auto"
+	Scheme              string   `json:"scheme"`              // "http" или "https", пустое значение - определить по хосту
+	RPCPath             string   `json:"rpcPath"`             // Путь к RPC, по умолчанию /transmission/rpc
+	CACertPath          string   `json:"caCertPath"`          // PEM-файл с дополнительными корневыми сертификатами
+	ClientCertPath      string   `json:"clientCertPath"`      // Клиентский сертификат для mTLS
+	ClientKeyPath       string   `json:"clientKeyPath"`       // Ключ клиентского сертификата для mTLS
+	AllowInsecure       bool     `json:"allowInsecure"`       // Не проверять сертификат сервера
+	ConnectTimeout      int      `json:"connectTimeout"`      // Таймаут подключения в секундах (0 - по умолчанию)
+	RequestTimeout      int      `json:"requestTimeout"`      // Таймаут запроса RPC в секундах (0 - по умолчанию)
 	MaxUploadRatio      float64  `json:"maxUploadRatio"`      // Maximum upload ratio before stopping torrent (0 means unlimited)
 	SlowSpeedLimit      int      `json:"slowSpeedLimit"`      // Speed limit for slow mode in KiB/s or MiB/s
 	SlowSpeedUnit       string   `json:"slowSpeedUnit"`       // Unit for slow speed limit: "KiB/s" or "MiB/s"
 	DownloadPaths       []string `json:"downloadPaths"`       // История каталогов для скачивания
 	DefaultDownloadPath string   `json:"defaultDownloadPath"` // Последний известный путь по умолчанию из Transmission
 }
+
+// Config represents the application configuration
+type Config struct {
+	Host                string           `json:"host"`
+	Port                int              `json:"port"`
+	Username            string           `json:"username"`
+	Password            string           `json:"password"`
+	Scheme              string           `json:"scheme"`
+	RPCPath             string           `json:"rpcPath"`
+	CACertPath          string           `json:"caCertPath"`
+	ClientCertPath      string           `json:"clientCertPath"`
+	ClientKeyPath       string           `json:"clientKeyPath"`
+	AllowInsecure       bool             `json:"allowInsecure"`
+	ConnectTimeout      int              `json:"connectTimeout"`
+	RequestTimeout      int              `json:"requestTimeout"`
+	Language            string           `json:"language"`            // Added for localization support
+	Theme               string           `json:"theme"`               // Added for theme support: "light", "dark", "auto"
+	MaxUploadRatio      float64          `json:"maxUploadRatio"`      // Maximum upload ratio before stopping torrent (0 means unlimited)
+	SlowSpeedLimit      int              `json:"slowSpeedLimit"`      // Speed limit for slow mode in KiB/s or MiB/s
+	SlowSpeedUnit       string           `json:"slowSpeedUnit"`       // Unit for slow speed limit: "KiB/s" or "MiB/s"
+	DownloadPaths       []string         `json:"downloadPaths"`       // История каталогов для скачивания
+	DefaultDownloadPath string           `json:"defaultDownloadPath"` // Последний известный путь по умолчанию из Transmission
+	Profiles            []ServerProfile  `json:"profiles"`            // Сохраненные профили серверов
+	ActiveProfile       string           `json:"activeProfile"`       // ID активного профиля
+	PolicyRules         []PolicyRule     `json:"policyRules"`         // Правила обслуживания раздач
+	AutomationRules     []AutomationRule `json:"automationRules"`     // Правила автоматизации
+}
+
+// DefaultProfileID идентификатор профиля, создаваемого при миграции старой конфигурации
+const DefaultProfileID = "default"
+
+// FindProfile возвращает профиль по идентификатору или nil
+func (c *Config) FindProfile(id string) *ServerProfile {
+	for i := range c.Profiles {
+		if c.Profiles[i].ID == id {
+			return &c.Profiles[i]
+		}
+	}
+	return nil
+}
+
+// ActiveServerProfile возвращает активный профиль или nil, если профилей нет
+func (c *Config) ActiveServerProfile() *ServerProfile {
+	return c.FindProfile(c.ActiveProfile)
+}
+
+// ApplyProfile делает профиль активным и копирует его параметры в плоские поля конфигурации
+func (c *Config) ApplyProfile(p *ServerProfile) {
+	c.ActiveProfile = p.ID
+	c.Host = p.Host
+	c.Port = p.Port
+	c.Username = p.Username
+	c.Password = p.Password
+	c.Scheme = p.Scheme
+	c.RPCPath = p.RPCPath
+	c.CACertPath = p.CACertPath
+	c.ClientCertPath = p.ClientCertPath
+	c.ClientKeyPath = p.ClientKeyPath
+	c.AllowInsecure = p.AllowInsecure
+	c.ConnectTimeout = p.ConnectTimeout
+	c.RequestTimeout = p.RequestTimeout
+	c.MaxUploadRatio = p.MaxUploadRatio
+	c.SlowSpeedLimit = p.SlowSpeedLimit
+	c.SlowSpeedUnit = p.SlowSpeedUnit
+	c.DownloadPaths = p.DownloadPaths
+	c.DefaultDownloadPath = p.DefaultDownloadPath
+}
+
+// SyncActiveProfile переносит плоские поля конфигурации в активный профиль.
+// Если активный профиль не найден, а подключение настроено, создается новый профиль.
+func (c *Config) SyncActiveProfile() {
+	profile := c.ActiveServerProfile()
+	if profile == nil {
+		if c.Host == "" {
+			return
+		}
+		// Параметры относятся к отсутствующему профилю: создаем новый, а не перезаписываем чужой
+		c.Profiles = append(c.Profiles, ServerProfile{ID: c.missingProfileID(), Name: c.Host})
+		profile = &c.Profiles[len(c.Profiles)-1]
+		c.ActiveProfile = profile.ID
+	}
+
+	profile.Host = c.Host
+	profile.Port = c.Port
+	profile.Username = c.Username
+	profile.Password = c.Password
+	profile.Scheme = c.Scheme
+	profile.RPCPath = c.RPCPath
+	profile.CACertPath = c.CACertPath
+	profile.ClientCertPath = c.ClientCertPath
+	profile.ClientKeyPath = c.ClientKeyPath
+	profile.AllowInsecure = c.AllowInsecure
+	profile.ConnectTimeout = c.ConnectTimeout
+	profile.RequestTimeout = c.RequestTimeout
+	profile.MaxUploadRatio = c.MaxUploadRatio
+	profile.SlowSpeedLimit = c.SlowSpeedLimit
+	profile.SlowSpeedUnit = c.SlowSpeedUnit
+	profile.DownloadPaths = c.DownloadPaths
+	profile.DefaultDownloadPath = c.DefaultDownloadPath
+}
+
+// missingProfileID возвращает идентификатор для профиля, создаваемого из плоских полей
+func (c *Config) missingProfileID() string {
+	if c.ActiveProfile != "" {
+		return c.ActiveProfile
+	}
+	id := DefaultProfileID
+	for i := 2; c.FindProfile(id) != nil; i++ {
+		id = fmt.Sprintf("%s-%d", DefaultProfileID, i)
+	}
+	return id
+}
+
+// ConnectionProfile возвращает параметры текущего подключения в виде профиля
+func (c *Config) ConnectionProfile() ServerProfile {
+	return ServerProfile{
+		ID:             c.ActiveProfile,
+		Host:           c.Host,
+		Port:           c.Port,
+		Username:       c.Username,
+		Password:       c.Password,
+		Scheme:         c.Scheme,
+		RPCPath:        c.RPCPath,
+		CACertPath:     c.CACertPath,
+		ClientCertPath: c.ClientCertPath,
+		ClientKeyPath:  c.ClientKeyPath,
+		AllowInsecure:  c.AllowInsecure,
+		ConnectTimeout: c.ConnectTimeout,
+		RequestTimeout: c.RequestTimeout,
+	}
+}
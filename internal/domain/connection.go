@@ -0,0 +1,17 @@
+package domain
+
+// ConnectionState состояние подключения к серверу Transmission
+type ConnectionState string
+
+const (
+	ConnectionConnected    ConnectionState = "connected"
+	ConnectionReconnecting ConnectionState = "reconnecting"
+	ConnectionFailed       ConnectionState = "failed"
+)
+
+// ConnectionStatus описывает текущее состояние подключения для отображения в интерфейсе
+type ConnectionStatus struct {
+	State   ConnectionState
+	Attempt int    // Номер неудачной попытки подряд, 0 если подключение установлено
+	Error   string // Текст последней ошибки
+}
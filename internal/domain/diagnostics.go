@@ -0,0 +1,13 @@
+package domain
+
+// PortTestResult результат проверки доступности порта для входящих соединений
+type PortTestResult struct {
+	Open    bool
+	Message string // Локализованное описание результата
+}
+
+// BlocklistUpdateResult результат обновления списка блокировки
+type BlocklistUpdateResult struct {
+	Size    int64 // Количество правил в обновленном списке
+	Message string
+}
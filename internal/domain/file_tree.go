@@ -0,0 +1,29 @@
+package domain
+
+// WantedState состояние выбора файлов в папке
+type WantedState string
+
+const (
+	WantedAll   WantedState = "all"   // Загружаются все файлы
+	WantedNone  WantedState = "none"  // Ни один файл не загружается
+	WantedMixed WantedState = "mixed" // Загружается только часть файлов
+)
+
+// FilePriorityMixed приоритет папки, файлы которой имеют разные приоритеты
+const FilePriorityMixed FilePriority = "mixed"
+
+// FileTreeNode узел дерева содержимого торрента: файл или папка
+// с суммарными размером и прогрессом вложенных файлов
+type FileTreeNode struct {
+	Name      string
+	Path      string
+	IsFolder  bool
+	FileID    int // ID файла, -1 для папок
+	FileCount int // Количество файлов в узле, 1 для файла
+	Size      int64
+	Completed int64
+	Progress  float64
+	Wanted    WantedState
+	Priority  FilePriority
+	Children  []*FileTreeNode
+}
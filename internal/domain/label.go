@@ -0,0 +1,7 @@
+package domain
+
+// LabelCount метка и количество торрентов, которым она назначена
+type LabelCount struct {
+	Name  string
+	Count int
+}
@@ -0,0 +1,45 @@
+package domain
+
+// MetainfoVersion версия формата .torrent файла
+type MetainfoVersion string
+
+const (
+	MetainfoV1     MetainfoVersion = "v1"
+	MetainfoV2     MetainfoVersion = "v2"
+	MetainfoHybrid MetainfoVersion = "hybrid"
+)
+
+// TorrentPreviewFile файл из .torrent файла
+type TorrentPreviewFile struct {
+	Index int // Индекс файла, под которым его увидит Transmission
+	Path  string
+	Size  int64
+}
+
+// TorrentPreview сведения о .torrent файле, полученные без обращения к демону
+type TorrentPreview struct {
+	Name         string
+	Version      MetainfoVersion
+	InfoHash     string // SHA-1 info-словаря в hex, пусто для чистого v2
+	InfoHashV2   string // SHA-256 info-словаря в hex, пусто для v1
+	TotalSize    int64
+	PieceSize    int64
+	PieceCount   int64
+	Files        []TorrentPreviewFile
+	Trackers     []string
+	WebSeeds     []string
+	Comment      string
+	CreatedBy    string
+	CreationDate int64 // Unix-время, 0 если не указано
+	Private      bool
+}
+
+// MagnetPreview сведения из magnet-ссылки
+type MagnetPreview struct {
+	Name       string
+	InfoHash   string // SHA-1 info-hash в hex нижнего регистра, пусто для чистого v2
+	InfoHashV2 string // SHA-256 info-hash в hex нижнего регистра
+	Trackers   []string
+	WebSeeds   []string
+	Size       int64 // Точный размер (xl), 0 если не указан
+}
@@ -0,0 +1,36 @@
+package domain
+
+// TorrentPeer информация о подключенном пире
+type TorrentPeer struct {
+	Address           string
+	Port              int64
+	ClientName        string
+	Progress          float64 // Процент загрузки у пира (0-100)
+	DownloadSpeed     int64   // Скорость загрузки от пира, байт/с
+	UploadSpeed       int64   // Скорость отдачи пиру, байт/с
+	Flags             string  // Строка флагов Transmission, например "UDEI"
+	IsEncrypted       bool
+	IsIncoming        bool
+	IsUTP             bool
+	IsDownloadingFrom bool
+	IsUploadingTo     bool
+	IsSeed            bool
+}
+
+// PeersFrom количество пиров по источникам, из которых они получены
+type PeersFrom struct {
+	Tracker  int64
+	DHT      int64
+	PEX      int64
+	LPD      int64
+	Incoming int64
+	LTEP     int64
+	Cache    int64
+}
+
+// TorrentPeers подключенные пиры торрента и источники их получения
+type TorrentPeers struct {
+	Peers          []TorrentPeer
+	PeersFrom      PeersFrom
+	SeedsConnected int // Подключенные пиры, у которых есть все данные торрента
+}
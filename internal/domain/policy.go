@@ -0,0 +1,39 @@
+package domain
+
+// PolicyAction действие, которое политика выполняет над торрентом
+type PolicyAction string
+
+const (
+	PolicyActionStop           PolicyAction = "stop"
+	PolicyActionRemove         PolicyAction = "remove"
+	PolicyActionRemoveWithData PolicyAction = "removeWithData"
+	PolicyActionMove           PolicyAction = "move"
+)
+
+// PolicyRule правило обслуживания завершенных раздач. Условия объединяются через И,
+// пустое условие не учитывается. Исключения отменяют срабатывание правила.
+type PolicyRule struct {
+	ID             string       `json:"id"`
+	Name           string       `json:"name"`
+	Enabled        bool         `json:"enabled"`
+	MinRatio       float64      `json:"minRatio"`       // Рейтинг, начиная с которого правило срабатывает (0 - не учитывается)
+	MinAgeHours    int          `json:"minAgeHours"`    // Сколько часов назад торрент должен быть добавлен (0 - не учитывается)
+	Labels         []string     `json:"labels"`         // Торрент должен иметь одну из меток
+	Trackers       []string     `json:"trackers"`       // Адрес одного из трекеров должен содержать строку
+	ExemptLabels   []string     `json:"exemptLabels"`   // Торренты с этими метками не затрагиваются
+	ExemptTrackers []string     `json:"exemptTrackers"` // Торренты с этими трекерами не затрагиваются
+	Action         PolicyAction `json:"action"`
+	MoveLocation   string       `json:"moveLocation"` // Директория для действия move
+}
+
+// PolicyLogEntry запись журнала о действии, выполненном политикой
+type PolicyLogEntry struct {
+	Time        int64 // Unix-время
+	RuleID      string
+	RuleName    string
+	TorrentID   int64
+	TorrentName string
+	Action      PolicyAction
+	Success     bool
+	Error       string
+}
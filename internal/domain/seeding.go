@@ -0,0 +1,37 @@
+package domain
+
+// SeedLimitMode режим ограничения раздачи торрента
+type SeedLimitMode string
+
+const (
+	SeedLimitGlobal    SeedLimitMode = "global"    // Используются настройки сессии
+	SeedLimitCustom    SeedLimitMode = "custom"    // Используется собственный предел торрента
+	SeedLimitUnlimited SeedLimitMode = "unlimited" // Раздача не ограничивается
+)
+
+// SeedingPolicy условия остановки раздачи торрента, которые выполняет сам демон
+type SeedingPolicy struct {
+	RatioMode  SeedLimitMode
+	RatioLimit float64
+	IdleMode   SeedLimitMode
+	IdleLimit  int64 // Минуты без активности
+}
+
+// TorrentDetails подробные сведения о торренте
+type TorrentDetails struct {
+	ID          int64
+	Name        string
+	HashString  string
+	DownloadDir string
+	Comment     string
+	Creator     string
+	IsPrivate   bool
+	DateCreated int64 // Unix-время
+	AddedDate   int64 // Unix-время
+	DoneDate    int64 // Unix-время, 0 если загрузка не завершена
+	TotalSize   int64
+	PieceCount  int64
+	PieceSize   int64
+	Error       string
+	Seeding     SeedingPolicy
+}
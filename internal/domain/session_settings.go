@@ -0,0 +1,57 @@
+package domain
+
+// EncryptionMode режим шифрования соединений с пирами
+type EncryptionMode string
+
+const (
+	EncryptionRequired  EncryptionMode = "required"
+	EncryptionPreferred EncryptionMode = "preferred"
+	EncryptionTolerated EncryptionMode = "tolerated"
+)
+
+// QueueSettings настройки очередей загрузки и раздачи
+type QueueSettings struct {
+	DownloadQueueEnabled bool
+	DownloadQueueSize    int64
+	SeedQueueEnabled     bool
+	SeedQueueSize        int64
+	QueueStalledEnabled  bool
+	QueueStalledMinutes  int64 // Торренты без активности дольше этого времени не занимают место в очереди
+}
+
+// SessionSettings настройки демона Transmission, доступные для редактирования
+type SessionSettings struct {
+	// Сеть
+	PeerPort              int64
+	PeerPortRandomOnStart bool
+	PortForwardingEnabled bool
+	Encryption            EncryptionMode
+	DHTEnabled            bool
+	PEXEnabled            bool
+	LPDEnabled            bool
+	UTPEnabled            bool
+	PeerLimitGlobal       int64
+	PeerLimitPerTorrent   int64
+	BlocklistEnabled      bool
+	BlocklistURL          string
+	BlocklistSize         int64 // Только для чтения: количество правил в списке блокировки
+
+	// Очереди
+	QueueSettings
+
+	// Файлы
+	IncompleteDirEnabled      bool
+	IncompleteDir             string
+	RenamePartialFiles        bool
+	StartAddedTorrents        bool
+	TrashOriginalTorrentFiles bool
+	CacheSizeMB               int64
+
+	// Скрипты
+	ScriptTorrentAddedEnabled        bool
+	ScriptTorrentAddedFilename       string
+	ScriptTorrentDoneEnabled         bool
+	ScriptTorrentDoneFilename        string
+	ScriptTorrentDoneSeedingEnabled  bool
+	ScriptTorrentDoneSeedingFilename string
+}
@@ -6,4 +6,5 @@ type SessionStats struct {
 	TotalUploadSpeed    int64  // Общая скорость отдачи в байтах/с
 	FreeSpace           int64  // Свободное место на диске в байтах
 	TransmissionVersion string // Версия Transmission
+	AltSpeedEnabled     bool   // Включены альтернативные ограничения скорости ("черепаший режим")
 }
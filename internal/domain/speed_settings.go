@@ -0,0 +1,31 @@
+package domain
+
+// Дни расписания альтернативной скорости (битовая маска, как в Transmission)
+const (
+	ScheduleSunday    int64 = 1 << 0
+	ScheduleMonday    int64 = 1 << 1
+	ScheduleTuesday   int64 = 1 << 2
+	ScheduleWednesday int64 = 1 << 3
+	ScheduleThursday  int64 = 1 << 4
+	ScheduleFriday    int64 = 1 << 5
+	ScheduleSaturday  int64 = 1 << 6
+	ScheduleWeekdays        = ScheduleMonday | ScheduleTuesday | ScheduleWednesday | ScheduleThursday | ScheduleFriday
+	ScheduleWeekend         = ScheduleSunday | ScheduleSaturday
+	ScheduleAllDays         = ScheduleWeekdays | ScheduleWeekend
+)
+
+// SpeedSettings ограничения скорости сессии Transmission, включая
+// альтернативные ("черепаший режим") и расписание их включения
+type SpeedSettings struct {
+	SpeedLimitDown        int64 // КБ/с
+	SpeedLimitDownEnabled bool
+	SpeedLimitUp          int64 // КБ/с
+	SpeedLimitUpEnabled   bool
+	AltSpeedDown          int64 // КБ/с
+	AltSpeedUp            int64 // КБ/с
+	AltSpeedEnabled       bool
+	AltSpeedTimeEnabled   bool
+	AltSpeedTimeBegin     int64 // Минуты после полуночи
+	AltSpeedTimeEnd       int64 // Минуты после полуночи
+	AltSpeedTimeDay       int64 // Битовая маска дней Schedule*
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "context"
+
 type TorrentStatus string
 
 const (
@@ -11,27 +13,41 @@ const (
 	StatusQueued      TorrentStatus = "queued"
 	StatusQueuedCheck TorrentStatus = "queuedCheck"    // Очередь на проверку
 	StatusQueuedDown  TorrentStatus = "queuedDownload" // Очередь на загрузку
+	StatusMoving      TorrentStatus = "moving"         // Перемещение данных в другую директорию
+)
+
+// FilePriority приоритет загрузки файла торрента
+type FilePriority string
+
+const (
+	FilePriorityLow    FilePriority = "low"
+	FilePriorityNormal FilePriority = "normal"
+	FilePriorityHigh   FilePriority = "high"
 )
 
 // Структура для представления файла в торренте
 type TorrentFile struct {
-	ID       int
-	Name     string
-	Path     string
-	Size     int64
-	Progress float64
-	Wanted   bool
+	ID        int
+	Name      string
+	Path      string
+	Size      int64
+	Completed int64
+	Progress  float64
+	Wanted    bool
+	Priority  FilePriority
 }
 
 type Torrent struct {
 	ID                     int64
+	ServerID               string // ID профиля сервера, заполняется в объединенном списке
 	Name                   string
+	DownloadDir            string
 	Status                 TorrentStatus
 	Progress               float64
 	Size                   int64 // Возвращаем тип int64
 	SizeFormatted          string
 	UploadRatio            float64
-	SeedsConnected         int
+	PeersSendingToUs       int // Пиры, от которых сейчас идет загрузка; простаивающие сиды не учитываются
 	SeedsTotal             int
 	PeersConnected         int
 	PeersTotal             int
@@ -42,25 +58,70 @@ type Torrent struct {
 	DownloadSpeedFormatted string
 	UploadSpeedFormatted   string
 	IsSlowMode             bool
+	Trackers               []TrackerAnnounce
+	Labels                 []string
+	AddedDate              int64 // Unix-время добавления
+	IsStalled              bool
+	QueuePosition          int64
+}
+
+// TorrentDiff изменения списка торрентов с предыдущего обновления
+type TorrentDiff struct {
+	Added   []Torrent
+	Changed []Torrent
+	Removed []int64
+	Full    bool // true, если выполнялась полная синхронизация
 }
 
 type TorrentRepository interface {
-	GetAll() ([]Torrent, error)
-	Add(url string, downloadDir string) error
-	AddFile(filepath string, downloadDir string) error
-	Remove(id int64, deleteData bool) error
-	Start(ids []int64) error
-	Stop(ids []int64) error
-	GetSessionStats() (*SessionStats, error) // Новый метод для получения статистики сессии
+	GetAll(ctx context.Context) ([]Torrent, error)
+	// GetRecentlyActive возвращает недавно изменившиеся торренты и ID удаленных
+	GetRecentlyActive(ctx context.Context) ([]Torrent, []int64, error)
+	Add(ctx context.Context, url string, options AddTorrentOptions) (*AddTorrentResult, error)
+	AddFile(ctx context.Context, filepath string, options AddTorrentOptions) (*AddTorrentResult, error)
+	Remove(ctx context.Context, id int64, deleteData bool) error
+	Start(ctx context.Context, ids []int64) error
+	Stop(ctx context.Context, ids []int64) error
+	GetSessionStats(ctx context.Context) (*SessionStats, error) // Новый метод для получения статистики сессии
 
 	// Новые методы для работы с файлами
-	GetTorrentFiles(id int64) ([]TorrentFile, error)
-	SetFilesWanted(id int64, fileIds []int, wanted bool) error
-	SetTorrentSpeedLimit(ids []int64, downloadLimit int64, uploadLimit int64) error
+	GetTorrentFiles(ctx context.Context, id int64) ([]TorrentFile, error)
+	SetFilesPriority(ctx context.Context, id int64, fileIds []int, priority FilePriority) error
+	SetFilesWanted(ctx context.Context, id int64, fileIds []int, wanted bool) error
+	SetTorrentSpeedLimit(ctx context.Context, ids []int64, downloadLimit int64, uploadLimit int64) error
 
 	// Метод для верификации торрента
-	VerifyTorrent(id int64) error
+	VerifyTorrent(ctx context.Context, id int64) error
 
 	// Новые методы для работы с каталогами
-	GetDefaultDownloadDir() (string, error)
+	GetDefaultDownloadDir(ctx context.Context) (string, error)
+
+	// Методы для работы с трекерами
+	GetTorrentTrackers(ctx context.Context, id int64) ([]TorrentTracker, error)
+	AddTrackers(ctx context.Context, id int64, announceURLs []string) error
+	ReplaceTracker(ctx context.Context, id int64, trackerID int64, announceURL string) error
+	ReplaceTrackers(ctx context.Context, id int64, replacements []TrackerReplacement) error
+	RemoveTrackers(ctx context.Context, id int64, trackerIDs []int64) error
+	GetTorrentPeers(ctx context.Context, id int64) (*TorrentPeers, error)
+	MoveTorrents(ctx context.Context, ids []int64, newLocation string, move bool) error
+	GetSpeedSettings(ctx context.Context) (*SpeedSettings, error)
+	SetSpeedSettings(ctx context.Context, settings SpeedSettings) error
+	SetAltSpeedEnabled(ctx context.Context, enabled bool) error
+	GetSessionSettings(ctx context.Context) (*SessionSettings, error)
+	UpdateSessionSettings(ctx context.Context, settings SessionSettings) error
+	TestPort(ctx context.Context) (bool, error)
+	UpdateBlocklist(ctx context.Context) (int64, error)
+	QueueMoveTop(ctx context.Context, ids []int64) error
+	QueueMoveUp(ctx context.Context, ids []int64) error
+	QueueMoveDown(ctx context.Context, ids []int64) error
+	QueueMoveBottom(ctx context.Context, ids []int64) error
+	StartNow(ctx context.Context, ids []int64) error
+	GetQueueSettings(ctx context.Context) (*QueueSettings, error)
+	SetQueueSettings(ctx context.Context, settings QueueSettings) error
+	SetLabels(ctx context.Context, ids []int64, labels []string) error
+	AddLabels(ctx context.Context, ids []int64, labels []string) error
+	RemoveLabels(ctx context.Context, ids []int64, labels []string) error
+	GetTorrentDetails(ctx context.Context, id int64) (*TorrentDetails, error)
+	SetSeedingPolicy(ctx context.Context, ids []int64, policy SeedingPolicy) error
+	RenameTorrentPath(ctx context.Context, id int64, oldPath string, newName string) ([]TorrentFile, error)
 }
@@ -0,0 +1,76 @@
+package domain
+
+// TrackerAnnounce краткие сведения о трекере, получаемые вместе со списком торрентов
+type TrackerAnnounce struct {
+	ID       int64
+	Announce string
+	Tier     int64
+}
+
+// TorrentTracker информация о трекере торрента и результатах последних обращений к нему
+type TorrentTracker struct {
+	ID                    int64
+	Announce              string
+	Scrape                string
+	SiteName              string
+	Host                  string
+	Tier                  int64
+	IsBackup              bool
+	LastAnnounceTime      int64 // Unix-время, 0 если анонса еще не было
+	LastAnnounceSucceeded bool
+	LastAnnounceTimedOut  bool
+	LastAnnounceResult    string
+	LastAnnouncePeerCount int64
+	NextAnnounceTime      int64 // Unix-время
+	LastScrapeTime        int64 // Unix-время, 0 если запроса статистики еще не было
+	LastScrapeSucceeded   bool
+	LastScrapeTimedOut    bool
+	LastScrapeResult      string
+	NextScrapeTime        int64 // Unix-время
+	SeederCount           int64
+	LeecherCount          int64
+	DownloadCount         int64
+	Error                 string // Сообщение об ошибке последнего анонса или запроса статистики
+}
+
+// TrackerMatchMode способ сопоставления адреса трекера при массовой замене
+type TrackerMatchMode string
+
+const (
+	TrackerMatchExact  TrackerMatchMode = "exact"
+	TrackerMatchPrefix TrackerMatchMode = "prefix"
+	TrackerMatchRegex  TrackerMatchMode = "regex"
+)
+
+// TrackerRewriteChange одна планируемая замена адреса трекера
+type TrackerRewriteChange struct {
+	TorrentID   int64
+	TorrentName string
+	TrackerID   int64
+	OldURL      string
+	NewURL      string
+	Error       string // Заполнено, если новый адрес некорректен; такая замена не будет применена
+}
+
+// TrackerReplacement замена адреса одного трекера. Если OldURL задан, замена
+// применяется только пока трекер с этим ID все еще имеет прежний адрес.
+type TrackerReplacement struct {
+	TrackerID int64
+	OldURL    string
+	NewURL    string
+}
+
+// TrackerRewriteResult результат замены трекеров одного торрента
+type TrackerRewriteResult struct {
+	TorrentID   int64
+	TorrentName string
+	Success     bool
+	Error       string
+}
+
+// TrackerRewriteReport отчет о массовой замене: план изменений и, после применения, результаты
+type TrackerRewriteReport struct {
+	DryRun  bool
+	Changes []TrackerRewriteChange
+	Results []TrackerRewriteResult
+}
@@ -1,13 +1,25 @@
 package infrastructure
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"transmission-client-go/internal/domain"
 )
 
+const (
+	errProfileNotFound    = "profile not found"
+	errProfileNameEmpty   = "profile name cannot be empty"
+	errCannotDeleteActive = "cannot delete the active profile"
+	errConfigNotFound     = "configuration not found"
+	profileIDBytes        = 8
+)
+
 // ConfigFormat представляет формат файла конфигурации
 type ConfigFormat struct {
 	// Зашифрованные данные конфигурации
@@ -49,6 +61,7 @@ func (s *ConfigService) LoadConfig() (*domain.Config, error) {
 	var config domain.Config
 	if err := json.Unmarshal(data, &config); err == nil && config.Host != "" {
 		// Это старый формат, сразу возвращаем его и мигрируем при следующем сохранении
+		s.normalizeProfiles(&config)
 		return &config, nil
 	}
 
@@ -69,6 +82,7 @@ func (s *ConfigService) LoadConfig() (*domain.Config, error) {
 		return nil, fmt.Errorf("failed to decrypt config: %w", err)
 	}
 
+	s.normalizeProfiles(&decryptedConfig)
 	return &decryptedConfig, nil
 }
 
@@ -85,6 +99,9 @@ func (s *ConfigService) SaveConfig(config *domain.Config) error {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
+	// Переносим текущие параметры подключения в активный профиль
+	config.SyncActiveProfile()
+
 	// Шифруем конфигурацию
 	encryptedData, err := s.encryptionService.EncryptConfig(config)
 	if err != nil {
@@ -118,4 +135,174 @@ func (s *ConfigService) getConfigPath() (string, error) {
 	}
 
 	return filepath.Join(configDir, "transmission-client", "config.json"), nil
-}
\ No newline at end of file
+}
+
+// normalizeProfiles приводит плоские поля конфигурации в соответствие с активным профилем
+// и создает профиль по умолчанию для конфигураций, сохраненных до появления профилей
+func (s *ConfigService) normalizeProfiles(config *domain.Config) {
+	if profile := config.ActiveServerProfile(); profile != nil {
+		config.ApplyProfile(profile)
+		return
+	}
+	config.SyncActiveProfile()
+}
+
+// loadExistingConfig загружает конфигурацию и возвращает ошибку, если она еще не создана
+func (s *ConfigService) loadExistingConfig() (*domain.Config, error) {
+	config, err := s.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		return nil, errors.New(errConfigNotFound)
+	}
+	return config, nil
+}
+
+// ListProfiles возвращает список сохраненных профилей серверов
+func (s *ConfigService) ListProfiles() ([]domain.ServerProfile, error) {
+	config, err := s.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		return []domain.ServerProfile{}, nil
+	}
+	return config.Profiles, nil
+}
+
+// CreateProfile добавляет новый профиль сервера и возвращает обновленную конфигурацию
+func (s *ConfigService) CreateProfile(profile domain.ServerProfile) (*domain.Config, *domain.ServerProfile, error) {
+	if profile.Name == "" {
+		return nil, nil, errors.New(errProfileNameEmpty)
+	}
+
+	config, err := s.LoadConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	if config == nil {
+		config = &domain.Config{}
+	}
+
+	id, err := newProfileID()
+	if err != nil {
+		return nil, nil, err
+	}
+	profile.ID = id
+	config.Profiles = append(config.Profiles, profile)
+
+	// Первый профиль сразу становится активным
+	if config.ActiveServerProfile() == nil {
+		config.ApplyProfile(&config.Profiles[len(config.Profiles)-1])
+	}
+
+	if err := s.SaveConfig(config); err != nil {
+		return nil, nil, err
+	}
+	return config, config.FindProfile(id), nil
+}
+
+// RenameProfile меняет отображаемое имя профиля
+func (s *ConfigService) RenameProfile(id string, name string) (*domain.Config, error) {
+	if name == "" {
+		return nil, errors.New(errProfileNameEmpty)
+	}
+
+	config, err := s.loadExistingConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	profile := config.FindProfile(id)
+	if profile == nil {
+		return nil, errors.New(errProfileNotFound)
+	}
+	profile.Name = name
+
+	if err := s.SaveConfig(config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// DeleteProfile удаляет профиль. Активный профиль удалить нельзя
+func (s *ConfigService) DeleteProfile(id string) (*domain.Config, error) {
+	config, err := s.loadExistingConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if id == config.ActiveProfile {
+		return nil, errors.New(errCannotDeleteActive)
+	}
+
+	idx := slices.IndexFunc(config.Profiles, func(p domain.ServerProfile) bool {
+		return p.ID == id
+	})
+	if idx == -1 {
+		return nil, errors.New(errProfileNotFound)
+	}
+	config.Profiles = slices.Delete(config.Profiles, idx, idx+1)
+
+	if err := s.SaveConfig(config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// SetActiveProfile делает указанный профиль активным и возвращает обновленную конфигурацию
+func (s *ConfigService) SetActiveProfile(id string) (*domain.Config, error) {
+	config, err := s.loadExistingConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	profile := config.FindProfile(id)
+	if profile == nil {
+		return nil, errors.New(errProfileNotFound)
+	}
+	config.ApplyProfile(profile)
+
+	if err := s.SaveConfig(config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// newProfileID генерирует случайный идентификатор профиля
+func newProfileID() (string, error) {
+	buf := make([]byte, profileIDBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate profile id: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// SavePolicyRules заменяет правила обслуживания раздач
+func (s *ConfigService) SavePolicyRules(rules []domain.PolicyRule) (*domain.Config, error) {
+	config, err := s.loadExistingConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	config.PolicyRules = rules
+	if err := s.SaveConfig(config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// SaveAutomationRules заменяет правила автоматизации
+func (s *ConfigService) SaveAutomationRules(rules []domain.AutomationRule) (*domain.Config, error) {
+	config, err := s.loadExistingConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	config.AutomationRules = rules
+	if err := s.SaveConfig(config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
@@ -0,0 +1,243 @@
+package metainfo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// maxBencodeDepth ограничивает вложенность списков и словарей
+	maxBencodeDepth = 64
+
+	errUnexpectedEnd    = "unexpected end of data"
+	errInvalidInteger   = "invalid integer at offset %d"
+	errInvalidString    = "invalid string length at offset %d"
+	errInvalidToken     = "unexpected character %q at offset %d"
+	errInvalidDictKey   = "dictionary key must be a string at offset %d"
+	errTooDeep          = "nesting is too deep"
+	errTrailingData     = "trailing data at offset %d"
+	errDuplicateDictKey = "duplicate dictionary key %q"
+)
+
+// ErrInvalidBencode возвращается для данных, не являющихся корректным bencode
+var ErrInvalidBencode = errors.New("invalid bencode")
+
+// span границы закодированного значения в исходных данных
+type span struct {
+	start, end int
+}
+
+// decoder разбирает bencode в значения Go:
+// int64, string, []any и map[string]any
+type decoder struct {
+	data  []byte
+	pos   int
+	depth int
+
+	// rawKey ключ словаря верхнего уровня, для которого запоминаются
+	// исходные байты значения (нужно для вычисления info-hash)
+	rawKey string
+	raw    *span
+}
+
+// Decode разбирает bencode целиком и возвращает декодированное значение
+func Decode(data []byte) (any, error) {
+	d := &decoder{data: data}
+	return d.decodeAll()
+}
+
+// decodeAll разбирает одно значение и проверяет, что после него нет данных
+func (d *decoder) decodeAll() (any, error) {
+	value, err := d.decode()
+	if err != nil {
+		return nil, err
+	}
+	if d.pos != len(d.data) {
+		return nil, d.errorf(errTrailingData, d.pos)
+	}
+	return value, nil
+}
+
+// decode разбирает значение, начинающееся с текущей позиции
+func (d *decoder) decode() (any, error) {
+	if d.pos >= len(d.data) {
+		return nil, d.errorf(errUnexpectedEnd)
+	}
+
+	switch c := d.data[d.pos]; {
+	case c == 'i':
+		return d.decodeInt()
+	case c >= '0' && c <= '9':
+		return d.decodeString()
+	case c == 'l':
+		return d.decodeList()
+	case c == 'd':
+		return d.decodeDict()
+	default:
+		return nil, d.errorf(errInvalidToken, c, d.pos)
+	}
+}
+
+// decodeInt разбирает целое число вида i<число>e
+func (d *decoder) decodeInt() (int64, error) {
+	start := d.pos
+	d.pos++ // 'i'
+
+	end := d.indexFrom('e')
+	if end < 0 {
+		return 0, d.errorf(errUnexpectedEnd)
+	}
+
+	digits := string(d.data[d.pos:end])
+	if !validIntDigits(digits) {
+		return 0, d.errorf(errInvalidInteger, start)
+	}
+	value, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return 0, d.errorf(errInvalidInteger, start)
+	}
+
+	d.pos = end + 1
+	return value, nil
+}
+
+// validIntDigits отклоняет пустые числа, знак "+", "-0" и ведущие нули
+func validIntDigits(s string) bool {
+	digits, negative := strings.CutPrefix(s, "-")
+	return validUintDigits(digits) && !(negative && digits == "0")
+}
+
+// validUintDigits проверяет, что строка состоит только из цифр и не имеет ведущих нулей
+func validUintDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return len(s) == 1 || s[0] != '0'
+}
+
+// decodeString разбирает строку вида <длина>:<байты>
+func (d *decoder) decodeString() (string, error) {
+	start := d.pos
+
+	colon := d.indexFrom(':')
+	if colon < 0 {
+		return "", d.errorf(errUnexpectedEnd)
+	}
+
+	digits := string(d.data[d.pos:colon])
+	if !validUintDigits(digits) {
+		return "", d.errorf(errInvalidString, start)
+	}
+	length, err := strconv.Atoi(digits)
+	if err != nil {
+		return "", d.errorf(errInvalidString, start)
+	}
+	if length > len(d.data)-colon-1 {
+		return "", d.errorf(errUnexpectedEnd)
+	}
+
+	d.pos = colon + 1 + length
+	return string(d.data[colon+1 : d.pos]), nil
+}
+
+// decodeList разбирает список вида l<значения>e
+func (d *decoder) decodeList() ([]any, error) {
+	if err := d.enter(); err != nil {
+		return nil, err
+	}
+	defer d.leave()
+
+	list := []any{}
+	for {
+		if d.pos >= len(d.data) {
+			return nil, d.errorf(errUnexpectedEnd)
+		}
+		if d.data[d.pos] == 'e' {
+			d.pos++
+			return list, nil
+		}
+
+		value, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, value)
+	}
+}
+
+// decodeDict разбирает словарь вида d<ключ><значение>...e
+func (d *decoder) decodeDict() (map[string]any, error) {
+	if err := d.enter(); err != nil {
+		return nil, err
+	}
+	defer d.leave()
+
+	dict := map[string]any{}
+	for {
+		if d.pos >= len(d.data) {
+			return nil, d.errorf(errUnexpectedEnd)
+		}
+		if d.data[d.pos] == 'e' {
+			d.pos++
+			return dict, nil
+		}
+
+		if c := d.data[d.pos]; c < '0' || c > '9' {
+			return nil, d.errorf(errInvalidDictKey, d.pos)
+		}
+		key, err := d.decodeString()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := dict[key]; ok {
+			return nil, d.errorf(errDuplicateDictKey, key)
+		}
+
+		start := d.pos
+		value, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		if d.depth == 1 && d.rawKey != "" && key == d.rawKey {
+			d.raw = &span{start: start, end: d.pos}
+		}
+		dict[key] = value
+	}
+}
+
+// enter увеличивает глубину вложенности
+func (d *decoder) enter() error {
+	if d.depth >= maxBencodeDepth {
+		return d.errorf(errTooDeep)
+	}
+	d.depth++
+	d.pos++ // 'l' или 'd'
+	return nil
+}
+
+// leave уменьшает глубину вложенности
+func (d *decoder) leave() {
+	d.depth--
+}
+
+// indexFrom ищет символ начиная с текущей позиции
+func (d *decoder) indexFrom(c byte) int {
+	i := bytes.IndexByte(d.data[d.pos:], c)
+	if i < 0 {
+		return -1
+	}
+	return d.pos + i
+}
+
+// errorf оборачивает описание ошибки в ErrInvalidBencode
+func (d *decoder) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidBencode, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,88 @@
+package metainfo
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    any
+		wantErr bool
+	}{
+		{name: "integer", data: "i42e", want: int64(42)},
+		{name: "negative integer", data: "i-7e", want: int64(-7)},
+		{name: "zero", data: "i0e", want: int64(0)},
+		{name: "string", data: "5:hello", want: "hello"},
+		{name: "empty string", data: "0:", want: ""},
+		{name: "list", data: "li1e1:ae", want: []any{int64(1), "a"}},
+		{name: "dictionary", data: "d1:ai1e1:bl1:cee", want: map[string]any{"a": int64(1), "b": []any{"c"}}},
+		{name: "empty input", data: "", wantErr: true},
+		{name: "integer with plus sign", data: "i+5e", wantErr: true},
+		{name: "negative zero", data: "i-0e", wantErr: true},
+		{name: "integer with leading zero", data: "i03e", wantErr: true},
+		{name: "empty integer", data: "ie", wantErr: true},
+		{name: "integer overflow", data: "i9223372036854775808e", wantErr: true},
+		{name: "string length with leading zero", data: "05:hello", wantErr: true},
+		{name: "string length with sign", data: "+5:hello", wantErr: true},
+		{name: "string length with inner sign", data: "1+4:hello", wantErr: true},
+		{name: "string longer than data", data: "10:hello", wantErr: true},
+		{name: "dictionary key length with leading zero", data: "d01:ai1ee", wantErr: true},
+		{name: "non-string dictionary key", data: "di1ei2ee", wantErr: true},
+		{name: "duplicate dictionary key", data: "d1:ai1e1:ai2ee", wantErr: true},
+		{name: "unterminated list", data: "li1e", wantErr: true},
+		{name: "trailing data", data: "i1ei2e", wantErr: true},
+		{name: "unknown token", data: "x", wantErr: true},
+		{name: "too deep", data: strings.Repeat("l", maxBencodeDepth+1) + strings.Repeat("e", maxBencodeDepth+1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Decode([]byte(tt.data))
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidBencode) {
+					t.Fatalf("Decode(%q) error = %v, want ErrInvalidBencode", tt.data, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Decode(%q) error = %v", tt.data, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Decode(%q) = %#v, want %#v", tt.data, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidIntDigits(t *testing.T) {
+	tests := []struct {
+		digits string
+		want   bool
+	}{
+		{"0", true},
+		{"7", true},
+		{"-7", true},
+		{"1024", true},
+		{"", false},
+		{"-", false},
+		{"-0", false},
+		{"00", false},
+		{"012", false},
+		{"-012", false},
+		{"+5", false},
+		{"5-", false},
+		{" 5", false},
+		{"1e3", false},
+	}
+
+	for _, tt := range tests {
+		if got := validIntDigits(tt.digits); got != tt.want {
+			t.Errorf("validIntDigits(%q) = %v, want %v", tt.digits, got, tt.want)
+		}
+	}
+}
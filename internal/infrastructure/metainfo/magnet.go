@@ -0,0 +1,178 @@
+package metainfo
+
+import (
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"transmission-client-go/internal/domain"
+)
+
+const (
+	magnetScheme = "magnet"
+
+	// Префиксы xt для BitTorrent v1 (SHA-1) и v2 (multihash SHA-256)
+	btihPrefix = "urn:btih:"
+	btmhPrefix = "urn:btmh:"
+
+	// sha256MultihashPrefix код multihash sha2-256 и длина дайджеста (32 байта)
+	sha256MultihashPrefix = "1220"
+
+	errNotMagnet        = "not a magnet link"
+	errMissingInfoHash  = "magnet link has no BitTorrent info-hash"
+	errInvalidInfoHash  = "invalid info-hash %q"
+	errConflictingHash  = "magnet link has several different info-hashes"
+	errInvalidExactSize = "invalid exact length %q"
+)
+
+// ErrInvalidMagnet возвращается для некорректных magnet-ссылок
+var ErrInvalidMagnet = errors.New("invalid magnet link")
+
+// IsMagnet проверяет, похожа ли строка на magnet-ссылку
+func IsMagnet(link string) bool {
+	scheme, _, ok := strings.Cut(strings.TrimSpace(link), ":")
+	return ok && strings.EqualFold(scheme, magnetScheme)
+}
+
+// ParseMagnet разбирает magnet-ссылку и приводит info-hash к нижнему регистру hex
+func ParseMagnet(link string) (*domain.MagnetPreview, error) {
+	if !IsMagnet(link) {
+		return nil, magnetErrorf(errNotMagnet)
+	}
+
+	parsed, err := url.Parse(strings.TrimSpace(link))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidMagnet, err)
+	}
+	query, err := url.ParseQuery(parsed.RawQuery)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidMagnet, err)
+	}
+
+	preview := &domain.MagnetPreview{
+		Name:     query.Get("dn"),
+		Trackers: uniqueValues(query, "tr"),
+		WebSeeds: uniqueValues(query, "ws"),
+	}
+
+	for _, xt := range indexedValues(query, "xt") {
+		if err := setInfoHash(preview, xt); err != nil {
+			return nil, err
+		}
+	}
+	if preview.InfoHash == "" && preview.InfoHashV2 == "" {
+		return nil, magnetErrorf(errMissingInfoHash)
+	}
+
+	if xl := query.Get("xl"); xl != "" {
+		size, err := strconv.ParseInt(xl, 10, 64)
+		if err != nil || size < 0 {
+			return nil, magnetErrorf(errInvalidExactSize, xl)
+		}
+		preview.Size = size
+	}
+
+	return preview, nil
+}
+
+// setInfoHash разбирает параметр xt. Неизвестные типы urn пропускаются
+func setInfoHash(preview *domain.MagnetPreview, xt string) error {
+	lower := strings.ToLower(xt)
+	switch {
+	case strings.HasPrefix(lower, btihPrefix):
+		hash, err := normalizeBTIH(xt[len(btihPrefix):])
+		if err != nil {
+			return err
+		}
+		return assignHash(&preview.InfoHash, hash)
+	case strings.HasPrefix(lower, btmhPrefix):
+		hash, err := normalizeBTMH(xt[len(btmhPrefix):])
+		if err != nil {
+			return err
+		}
+		return assignHash(&preview.InfoHashV2, hash)
+	default:
+		return nil
+	}
+}
+
+// assignHash запоминает info-hash и отклоняет ссылки с разными хэшами одного типа
+func assignHash(target *string, hash string) error {
+	if *target != "" && *target != hash {
+		return magnetErrorf(errConflictingHash)
+	}
+	*target = hash
+	return nil
+}
+
+// normalizeBTIH приводит SHA-1 info-hash в hex (40 символов) или base32 (32 символа) к hex
+func normalizeBTIH(value string) (string, error) {
+	switch len(value) {
+	case 40:
+		if _, err := hex.DecodeString(value); err != nil {
+			return "", magnetErrorf(errInvalidInfoHash, value)
+		}
+		return strings.ToLower(value), nil
+	case 32:
+		decoded, err := base32.StdEncoding.DecodeString(strings.ToUpper(value))
+		if err != nil {
+			return "", magnetErrorf(errInvalidInfoHash, value)
+		}
+		return hex.EncodeToString(decoded), nil
+	default:
+		return "", magnetErrorf(errInvalidInfoHash, value)
+	}
+}
+
+// normalizeBTMH извлекает SHA-256 info-hash из multihash в hex
+func normalizeBTMH(value string) (string, error) {
+	value = strings.ToLower(value)
+	digest, ok := strings.CutPrefix(value, sha256MultihashPrefix)
+	if !ok || len(digest) != 64 {
+		return "", magnetErrorf(errInvalidInfoHash, value)
+	}
+	if _, err := hex.DecodeString(digest); err != nil {
+		return "", magnetErrorf(errInvalidInfoHash, value)
+	}
+	return digest, nil
+}
+
+// indexedValues возвращает значения параметра вместе с нумерованными вариантами (xt.1, xt.2, ...)
+func indexedValues(query url.Values, key string) []string {
+	indexes := make(map[int]string)
+	for name := range query {
+		if prefix, suffix, ok := strings.Cut(name, "."); ok && prefix == key {
+			if index, err := strconv.Atoi(suffix); err == nil {
+				indexes[index] = name
+			}
+		}
+	}
+
+	values := slices.Clone(query[key])
+	for _, index := range slices.Sorted(maps.Keys(indexes)) {
+		values = append(values, query[indexes[index]]...)
+	}
+	return values
+}
+
+// uniqueValues возвращает непустые значения параметра без дубликатов
+func uniqueValues(query url.Values, key string) []string {
+	result := []string{}
+	for _, value := range indexedValues(query, key) {
+		value = strings.TrimSpace(value)
+		if value != "" && !slices.Contains(result, value) {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// magnetErrorf оборачивает описание ошибки в ErrInvalidMagnet
+func magnetErrorf(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidMagnet, fmt.Sprintf(format, args...))
+}
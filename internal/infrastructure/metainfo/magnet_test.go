@@ -0,0 +1,130 @@
+package metainfo
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"transmission-client-go/internal/domain"
+)
+
+const (
+	testHashHex    = "c12fe1c06bba254a9dc9f519b335aa7c1367a88a"
+	testHashBase32 = "YEX6DQDLXISUVHOJ6UM3GNNKPQJWPKEK"
+	testHashV2     = "1220" + "d1a6b0bfe2e0c0e1f6e8c3e1b0c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0"
+)
+
+func TestParseMagnet(t *testing.T) {
+	tests := []struct {
+		name    string
+		link    string
+		want    *domain.MagnetPreview
+		wantErr bool
+	}{
+		{
+			name: "hex info-hash with name and trackers",
+			link: "magnet:?xt=urn:btih:" + strings.ToUpper(testHashHex) + "&dn=Ubuntu+24.04&tr=udp%3A%2F%2Ftracker.example%3A80&tr=udp%3A%2F%2Ftracker.example%3A80&tr.1=http%3A%2F%2Fb%2Fannounce&xl=1024",
+			want: &domain.MagnetPreview{
+				Name:     "Ubuntu 24.04",
+				InfoHash: testHashHex,
+				Trackers: []string{"udp://tracker.example:80", "http://b/announce"},
+				WebSeeds: []string{},
+				Size:     1024,
+			},
+		},
+		{
+			name: "base32 info-hash",
+			link: "magnet:?xt=urn:btih:" + testHashBase32,
+			want: &domain.MagnetPreview{InfoHash: testHashHex, Trackers: []string{}, WebSeeds: []string{}},
+		},
+		{
+			name: "hybrid with v2 multihash and web seed",
+			link: "MAGNET:?xt=urn:btih:" + testHashHex + "&xt=urn:btmh:" + testHashV2 + "&ws=https%3A%2F%2Fmirror%2Ffile",
+			want: &domain.MagnetPreview{
+				InfoHash:   testHashHex,
+				InfoHashV2: strings.TrimPrefix(testHashV2, "1220"),
+				Trackers:   []string{},
+				WebSeeds:   []string{"https://mirror/file"},
+			},
+		},
+		{
+			name: "unknown urn is ignored",
+			link: "magnet:?xt=urn:ed2k:abc&xt.1=urn:btih:" + testHashHex,
+			want: &domain.MagnetPreview{InfoHash: testHashHex, Trackers: []string{}, WebSeeds: []string{}},
+		},
+		{name: "not a magnet", link: "https://example.com/file.torrent", wantErr: true},
+		{name: "no info-hash", link: "magnet:?dn=name", wantErr: true},
+		{name: "short info-hash", link: "magnet:?xt=urn:btih:abc", wantErr: true},
+		{name: "conflicting info-hashes", link: "magnet:?xt=urn:btih:" + testHashHex + "&xt=urn:btih:" + strings.Repeat("0", 40), wantErr: true},
+		{name: "same info-hash in both encodings", link: "magnet:?xt=urn:btih:" + testHashHex + "&xt=urn:btih:" + testHashBase32, want: &domain.MagnetPreview{InfoHash: testHashHex, Trackers: []string{}, WebSeeds: []string{}}},
+		{name: "v2 hash without sha256 prefix", link: "magnet:?xt=urn:btmh:" + strings.TrimPrefix(testHashV2, "1220"), wantErr: true},
+		{name: "negative exact length", link: "magnet:?xt=urn:btih:" + testHashHex + "&xl=-1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMagnet(tt.link)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidMagnet) {
+					t.Fatalf("ParseMagnet() error = %v, want ErrInvalidMagnet", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseMagnet() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseMagnet() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeBTIH(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{name: "lower hex", value: testHashHex, want: testHashHex},
+		{name: "upper hex", value: strings.ToUpper(testHashHex), want: testHashHex},
+		{name: "base32", value: testHashBase32, want: testHashHex},
+		{name: "lower base32", value: strings.ToLower(testHashBase32), want: testHashHex},
+		{name: "invalid hex", value: strings.Repeat("g", 40), wantErr: true},
+		{name: "invalid base32", value: strings.Repeat("1", 32), wantErr: true},
+		{name: "wrong length", value: testHashHex[:39], wantErr: true},
+		{name: "empty", value: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeBTIH(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("normalizeBTIH(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("normalizeBTIH(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsMagnet(t *testing.T) {
+	tests := []struct {
+		link string
+		want bool
+	}{
+		{"magnet:?xt=urn:btih:" + testHashHex, true},
+		{"  Magnet:?dn=x", true},
+		{"http://example.com/a.torrent", false},
+		{"magnet", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsMagnet(tt.link); got != tt.want {
+			t.Errorf("IsMagnet(%q) = %v, want %v", tt.link, got, tt.want)
+		}
+	}
+}
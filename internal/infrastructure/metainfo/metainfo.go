@@ -0,0 +1,326 @@
+package metainfo
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"transmission-client-go/internal/domain"
+)
+
+const (
+	// MaxMetainfoSize максимальный размер .torrent файла, который разбирается локально
+	MaxMetainfoSize = 32 << 20
+
+	errMissingInfo       = "missing info dictionary"
+	errMissingName       = "missing torrent name"
+	errMissingPieceSize  = "missing or invalid piece length"
+	errMissingFiles      = "info dictionary has neither length, files nor file tree"
+	errInvalidFileEntry  = "invalid file entry"
+	errInvalidFilePath   = "invalid file path"
+	errInvalidPieces     = "invalid pieces length"
+	errMetainfoTooLarge  = "torrent file is too large"
+	errInvalidBase64Data = "invalid base64 data"
+
+	// metaVersion2 значение "meta version" для торрентов BitTorrent v2
+	metaVersion2 = 2
+)
+
+// ErrInvalidMetainfo возвращается, если данные не являются .torrent файлом
+var ErrInvalidMetainfo = errors.New("invalid torrent metainfo")
+
+// ParseBase64 разбирает .torrent файл, закодированный в base64.
+// Допускается префикс data URL ("data:...;base64,").
+func ParseBase64(content string) (*domain.TorrentPreview, error) {
+	if i := strings.Index(content, ","); strings.HasPrefix(content, "data:") && i >= 0 {
+		content = content[i+1:]
+	}
+
+	if base64.StdEncoding.DecodedLen(len(content)) > MaxMetainfoSize {
+		return nil, invalidf(errMetainfoTooLarge)
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(content))
+	if err != nil {
+		return nil, invalidf(errInvalidBase64Data)
+	}
+	return Parse(data)
+}
+
+// Parse разбирает содержимое .torrent файла без обращения к демону
+func Parse(data []byte) (*domain.TorrentPreview, error) {
+	if len(data) > MaxMetainfoSize {
+		return nil, invalidf(errMetainfoTooLarge)
+	}
+
+	d := &decoder{data: data, rawKey: "info"}
+	value, err := d.decodeAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidMetainfo, err)
+	}
+
+	root, ok := value.(map[string]any)
+	if !ok {
+		return nil, invalidf(errMissingInfo)
+	}
+	info, ok := root["info"].(map[string]any)
+	if !ok || d.raw == nil {
+		return nil, invalidf(errMissingInfo)
+	}
+
+	name := utf8String(info, "name")
+	if name == "" {
+		return nil, invalidf(errMissingName)
+	}
+	pieceSize, ok := info["piece length"].(int64)
+	if !ok || pieceSize <= 0 {
+		return nil, invalidf(errMissingPieceSize)
+	}
+
+	preview := &domain.TorrentPreview{
+		Name:         name,
+		PieceSize:    pieceSize,
+		Trackers:     trackers(root),
+		WebSeeds:     stringList(root["url-list"]),
+		Comment:      utf8String(root, "comment"),
+		CreatedBy:    stringValue(root["created by"]),
+		CreationDate: intValue(root["creation date"]),
+		Private:      intValue(info["private"]) == 1,
+	}
+
+	rawInfo := data[d.raw.start:d.raw.end]
+	hasV1 := info["length"] != nil || info["files"] != nil
+	hasV2 := intValue(info["meta version"]) == metaVersion2 && info["file tree"] != nil
+
+	switch {
+	case hasV1 && hasV2:
+		preview.Version = domain.MetainfoHybrid
+	case hasV2:
+		preview.Version = domain.MetainfoV2
+	case hasV1:
+		preview.Version = domain.MetainfoV1
+	default:
+		return nil, invalidf(errMissingFiles)
+	}
+
+	if hasV1 {
+		sum := sha1.Sum(rawInfo)
+		preview.InfoHash = hex.EncodeToString(sum[:])
+
+		// Для гибридных торрентов Transmission использует список файлов v1
+		if preview.Files, err = v1Files(info, name); err != nil {
+			return nil, err
+		}
+		pieces, _ := info["pieces"].(string)
+		if len(pieces) == 0 || len(pieces)%sha1.Size != 0 {
+			return nil, invalidf(errInvalidPieces)
+		}
+		preview.PieceCount = int64(len(pieces) / sha1.Size)
+	}
+
+	if hasV2 {
+		sum := sha256.Sum256(rawInfo)
+		preview.InfoHashV2 = hex.EncodeToString(sum[:])
+
+		if !hasV1 {
+			tree, _ := info["file tree"].(map[string]any)
+			if preview.Files, err = v2Files(tree, name); err != nil {
+				return nil, err
+			}
+			for _, f := range preview.Files {
+				preview.PieceCount += (f.Size + pieceSize - 1) / pieceSize
+			}
+		}
+	}
+
+	for _, f := range preview.Files {
+		preview.TotalSize += f.Size
+	}
+	return preview, nil
+}
+
+// v1Files возвращает файлы из info-словаря v1.
+// Файлы выравнивания (BEP 47) Transmission не показывает, поэтому они пропускаются
+// и не занимают индексы.
+func v1Files(info map[string]any, name string) ([]domain.TorrentPreviewFile, error) {
+	if length, ok := info["length"].(int64); ok {
+		if length < 0 {
+			return nil, invalidf(errInvalidFileEntry)
+		}
+		return []domain.TorrentPreviewFile{{Index: 0, Path: name, Size: length}}, nil
+	}
+
+	entries, ok := info["files"].([]any)
+	if !ok || len(entries) == 0 {
+		return nil, invalidf(errMissingFiles)
+	}
+
+	files := make([]domain.TorrentPreviewFile, 0, len(entries))
+	for _, entry := range entries {
+		file, ok := entry.(map[string]any)
+		if !ok {
+			return nil, invalidf(errInvalidFileEntry)
+		}
+		length, ok := file["length"].(int64)
+		if !ok || length < 0 {
+			return nil, invalidf(errInvalidFileEntry)
+		}
+		if strings.Contains(stringValue(file["attr"]), "p") {
+			continue
+		}
+
+		parts := stringList(file["path.utf-8"])
+		if len(parts) == 0 {
+			parts = stringList(file["path"])
+		}
+		filePath, err := joinPath(name, parts)
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, domain.TorrentPreviewFile{
+			Index: len(files),
+			Path:  filePath,
+			Size:  length,
+		})
+	}
+	return files, nil
+}
+
+// v2Files обходит дерево файлов v2 в порядке ключей, как это делает Transmission
+func v2Files(tree map[string]any, name string) ([]domain.TorrentPreviewFile, error) {
+	if len(tree) == 0 {
+		return nil, invalidf(errMissingFiles)
+	}
+
+	// Однофайловый торрент v2: единственный ключ корня - имя торрента, и это лист
+	if node, ok := tree[name].(map[string]any); ok && len(tree) == 1 {
+		if leaf, ok := node[""].(map[string]any); ok {
+			length, ok := leaf["length"].(int64)
+			if !ok || length < 0 {
+				return nil, invalidf(errInvalidFileEntry)
+			}
+			return []domain.TorrentPreviewFile{{Index: 0, Path: name, Size: length}}, nil
+		}
+	}
+
+	var files []domain.TorrentPreviewFile
+	var walk func(node map[string]any, parts []string) error
+	walk = func(node map[string]any, parts []string) error {
+		for _, key := range slices.Sorted(maps.Keys(node)) {
+			child, ok := node[key].(map[string]any)
+			if !ok {
+				return invalidf(errInvalidFileEntry)
+			}
+
+			// Лист дерева: ключ "" содержит длину файла
+			if key == "" {
+				length, ok := child["length"].(int64)
+				if !ok || length < 0 {
+					return invalidf(errInvalidFileEntry)
+				}
+				filePath, err := joinPath(name, parts)
+				if err != nil {
+					return err
+				}
+				files = append(files, domain.TorrentPreviewFile{
+					Index: len(files),
+					Path:  filePath,
+					Size:  length,
+				})
+				continue
+			}
+
+			if err := walk(child, append(slices.Clone(parts), key)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := walk(tree, nil); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// joinPath собирает путь файла так же, как его показывает Transmission: <имя>/<путь>
+func joinPath(name string, parts []string) (string, error) {
+	if len(parts) == 0 {
+		return "", invalidf(errInvalidFilePath)
+	}
+	for _, part := range parts {
+		if part == "" || part == "." || part == ".." || strings.ContainsAny(part, "/\\") {
+			return "", invalidf(errInvalidFilePath)
+		}
+	}
+	return name + "/" + strings.Join(parts, "/"), nil
+}
+
+// trackers объединяет announce и announce-list без дубликатов, сохраняя порядок
+func trackers(root map[string]any) []string {
+	result := []string{}
+	add := func(url string) {
+		url = strings.TrimSpace(url)
+		if url != "" && !slices.Contains(result, url) {
+			result = append(result, url)
+		}
+	}
+
+	if tiers, ok := root["announce-list"].([]any); ok {
+		for _, tier := range tiers {
+			for _, url := range stringList(tier) {
+				add(url)
+			}
+		}
+	}
+	add(stringValue(root["announce"]))
+	return result
+}
+
+// utf8String возвращает строку, предпочитая вариант с суффиксом ".utf-8"
+func utf8String(dict map[string]any, key string) string {
+	if value := stringValue(dict[key+".utf-8"]); value != "" {
+		return value
+	}
+	return stringValue(dict[key])
+}
+
+// stringValue возвращает строку или пустую строку для значений другого типа
+func stringValue(value any) string {
+	s, _ := value.(string)
+	return s
+}
+
+// intValue возвращает число или 0 для значений другого типа
+func intValue(value any) int64 {
+	n, _ := value.(int64)
+	return n
+}
+
+// stringList возвращает строки из списка. Одиночная строка считается списком из одного элемента.
+func stringList(value any) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	default:
+		return nil
+	}
+}
+
+// invalidf оборачивает описание ошибки в ErrInvalidMetainfo
+func invalidf(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidMetainfo, fmt.Sprintf(format, args...))
+}
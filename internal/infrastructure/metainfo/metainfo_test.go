@@ -0,0 +1,230 @@
+package metainfo
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"transmission-client-go/internal/domain"
+)
+
+// encode кодирует значения для тестов: int, int64, string, []any и map[string]any
+func encode(value any) string {
+	switch v := value.(type) {
+	case int:
+		return fmt.Sprintf("i%de", v)
+	case int64:
+		return fmt.Sprintf("i%de", v)
+	case string:
+		return fmt.Sprintf("%d:%s", len(v), v)
+	case []any:
+		var b strings.Builder
+		b.WriteString("l")
+		for _, item := range v {
+			b.WriteString(encode(item))
+		}
+		b.WriteString("e")
+		return b.String()
+	case map[string]any:
+		var b strings.Builder
+		b.WriteString("d")
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			b.WriteString(encode(key))
+			b.WriteString(encode(v[key]))
+		}
+		b.WriteString("e")
+		return b.String()
+	default:
+		panic(fmt.Sprintf("unsupported type %T", value))
+	}
+}
+
+// leaf лист дерева файлов v2
+func leaf(length int) map[string]any {
+	return map[string]any{"": map[string]any{"length": length, "pieces root": strings.Repeat("r", 32)}}
+}
+
+func TestParse(t *testing.T) {
+	pieces := strings.Repeat("p", sha1.Size*2)
+
+	tests := []struct {
+		name        string
+		info        map[string]any
+		root        map[string]any
+		wantVersion domain.MetainfoVersion
+		wantFiles   []domain.TorrentPreviewFile
+		wantPieces  int64
+	}{
+		{
+			name:        "v1 single file",
+			info:        map[string]any{"name": "file.iso", "piece length": 16, "length": 20, "pieces": pieces},
+			wantVersion: domain.MetainfoV1,
+			wantFiles:   []domain.TorrentPreviewFile{{Index: 0, Path: "file.iso", Size: 20}},
+			wantPieces:  2,
+		},
+		{
+			name: "v1 multi file skips padding",
+			info: map[string]any{"name": "album", "piece length": 16, "pieces": pieces, "files": []any{
+				map[string]any{"length": 10, "path": []any{"cd1", "01.flac"}},
+				map[string]any{"length": 6, "path": []any{".pad", "6"}, "attr": "p"},
+				map[string]any{"length": 12, "path": []any{"cover.jpg"}, "path.utf-8": []any{"обложка.jpg"}},
+			}},
+			wantVersion: domain.MetainfoV1,
+			wantFiles: []domain.TorrentPreviewFile{
+				{Index: 0, Path: "album/cd1/01.flac", Size: 10},
+				{Index: 1, Path: "album/обложка.jpg", Size: 12},
+			},
+			wantPieces: 2,
+		},
+		{
+			name:        "v2 single file",
+			info:        map[string]any{"name": "file.iso", "piece length": 16, "meta version": 2, "file tree": map[string]any{"file.iso": leaf(40)}},
+			wantVersion: domain.MetainfoV2,
+			wantFiles:   []domain.TorrentPreviewFile{{Index: 0, Path: "file.iso", Size: 40}},
+			wantPieces:  3,
+		},
+		{
+			name: "v2 folder named like the torrent is not a single file",
+			info: map[string]any{"name": "data", "piece length": 16, "meta version": 2, "file tree": map[string]any{
+				"data": map[string]any{"data": leaf(16)},
+			}},
+			wantVersion: domain.MetainfoV2,
+			wantFiles:   []domain.TorrentPreviewFile{{Index: 0, Path: "data/data/data", Size: 16}},
+			wantPieces:  1,
+		},
+		{
+			name: "v2 multi file in key order",
+			info: map[string]any{"name": "album", "piece length": 16, "meta version": 2, "file tree": map[string]any{
+				"b.txt": leaf(17),
+				"a":     map[string]any{"c.txt": leaf(1)},
+			}},
+			wantVersion: domain.MetainfoV2,
+			wantFiles: []domain.TorrentPreviewFile{
+				{Index: 0, Path: "album/a/c.txt", Size: 1},
+				{Index: 1, Path: "album/b.txt", Size: 17},
+			},
+			wantPieces: 3,
+		},
+		{
+			name: "hybrid uses v1 file list",
+			info: map[string]any{
+				"name": "file.iso", "piece length": 16, "length": 20, "pieces": pieces,
+				"meta version": 2, "file tree": map[string]any{"file.iso": leaf(20)},
+			},
+			root:        map[string]any{"announce": "http://a/announce", "announce-list": []any{[]any{"http://b/announce", "http://a/announce"}}},
+			wantVersion: domain.MetainfoHybrid,
+			wantFiles:   []domain.TorrentPreviewFile{{Index: 0, Path: "file.iso", Size: 20}},
+			wantPieces:  2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := map[string]any{"info": tt.info}
+			maps.Copy(root, tt.root)
+			data := []byte(encode(root))
+
+			preview, err := Parse(data)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if preview.Version != tt.wantVersion {
+				t.Errorf("Version = %q, want %q", preview.Version, tt.wantVersion)
+			}
+			if !reflect.DeepEqual(preview.Files, tt.wantFiles) {
+				t.Errorf("Files = %+v, want %+v", preview.Files, tt.wantFiles)
+			}
+			if preview.PieceCount != tt.wantPieces {
+				t.Errorf("PieceCount = %d, want %d", preview.PieceCount, tt.wantPieces)
+			}
+
+			rawInfo := []byte(encode(tt.info))
+			sum1 := sha1.Sum(rawInfo)
+			sum2 := sha256.Sum256(rawInfo)
+			wantHash, wantHashV2 := hex.EncodeToString(sum1[:]), hex.EncodeToString(sum2[:])
+			if tt.wantVersion == domain.MetainfoV2 {
+				wantHash = ""
+			}
+			if tt.wantVersion == domain.MetainfoV1 {
+				wantHashV2 = ""
+			}
+			if preview.InfoHash != wantHash || preview.InfoHashV2 != wantHashV2 {
+				t.Errorf("hashes = %q, %q, want %q, %q", preview.InfoHash, preview.InfoHashV2, wantHash, wantHashV2)
+			}
+		})
+	}
+}
+
+func TestParseTrackers(t *testing.T) {
+	root := map[string]any{
+		"announce":      "http://a/announce",
+		"announce-list": []any{[]any{"http://b/announce", "http://a/announce"}, []any{"udp://c:80"}},
+		"info":          map[string]any{"name": "f", "piece length": 16, "length": 1, "pieces": strings.Repeat("p", sha1.Size)},
+	}
+
+	preview, err := Parse([]byte(encode(root)))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	want := []string{"http://b/announce", "http://a/announce", "udp://c:80"}
+	if !reflect.DeepEqual(preview.Trackers, want) {
+		t.Errorf("Trackers = %v, want %v", preview.Trackers, want)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	pieces := strings.Repeat("p", sha1.Size)
+
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"not bencode", "hello"},
+		{"not a dictionary", encode([]any{"info"})},
+		{"missing info", encode(map[string]any{"announce": "http://a"})},
+		{"missing name", encode(map[string]any{"info": map[string]any{"piece length": 16, "length": 1, "pieces": pieces}})},
+		{"missing piece length", encode(map[string]any{"info": map[string]any{"name": "f", "length": 1, "pieces": pieces}})},
+		{"no files", encode(map[string]any{"info": map[string]any{"name": "f", "piece length": 16, "pieces": pieces}})},
+		{"bad pieces", encode(map[string]any{"info": map[string]any{"name": "f", "piece length": 16, "length": 1, "pieces": "abc"}})},
+		{"path traversal", encode(map[string]any{"info": map[string]any{"name": "f", "piece length": 16, "pieces": pieces, "files": []any{
+			map[string]any{"length": 1, "path": []any{"..", "etc"}},
+		}}})},
+		{"v2 leaf without length", encode(map[string]any{"info": map[string]any{"name": "f", "piece length": 16, "meta version": 2, "file tree": map[string]any{
+			"f": map[string]any{"": map[string]any{}},
+		}}})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse([]byte(tt.data)); !errors.Is(err, ErrInvalidMetainfo) {
+				t.Errorf("Parse() error = %v, want ErrInvalidMetainfo", err)
+			}
+		})
+	}
+}
+
+func TestParseBase64(t *testing.T) {
+	data := encode(map[string]any{"info": map[string]any{"name": "f", "piece length": 16, "length": 1, "pieces": strings.Repeat("p", sha1.Size)}})
+	encoded := base64.StdEncoding.EncodeToString([]byte(data))
+
+	for _, content := range []string{encoded, "data:application/x-bittorrent;base64," + encoded} {
+		preview, err := ParseBase64(content)
+		if err != nil {
+			t.Fatalf("ParseBase64() error = %v", err)
+		}
+		if preview.Name != "f" {
+			t.Errorf("Name = %q, want %q", preview.Name, "f")
+		}
+	}
+
+	if _, err := ParseBase64("not base64!"); !errors.Is(err, ErrInvalidMetainfo) {
+		t.Errorf("ParseBase64() error = %v, want ErrInvalidMetainfo", err)
+	}
+}
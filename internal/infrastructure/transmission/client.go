@@ -2,62 +2,272 @@ package transmission
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"net"
+	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/hekmon/transmissionrpc/v3"
 )
 
+const (
+	defaultRPCPath        = "/transmission/rpc"
+	schemeHTTP            = "http"
+	schemeHTTPS           = "https"
+	defaultConnectTimeout = 10 * time.Second
+	defaultRequestTimeout = 60 * time.Second
+)
+
 type TransmissionConfig struct {
-	Host     string
-	Port     int
-	Username string
-	Password string
+	Host           string
+	Port           int
+	Username       string
+	Password       string
+	Scheme         string        // "http" или "https"; если пусто, определяется по префиксу хоста
+	RPCPath        string        // Путь к RPC; если пусто, берется из хоста или используется /transmission/rpc
+	CACertPath     string        // PEM-файл с дополнительными корневыми сертификатами
+	ClientCertPath string        // Клиентский сертификат для mTLS
+	ClientKeyPath  string        // Ключ клиентского сертификата
+	AllowInsecure  bool          // Отключает проверку сертификата сервера
+	ConnectTimeout time.Duration // Таймаут установки соединения; 0 - значение по умолчанию
+	RequestTimeout time.Duration // Таймаут одного вызова RPC; 0 - значение по умолчанию
 }
 
 type TransmissionClient struct {
-	client *transmissionrpc.Client
-	ctx    context.Context
+	client         *transmissionrpc.Client
+	rpc            *rpcCaller
+	requestTimeout time.Duration
+
+	versionMu  sync.Mutex
+	rpcVersion int64 // Версия RPC демона, 0 пока не запрошена
 }
 
 func NewTransmissionClient(config TransmissionConfig) (*TransmissionClient, error) {
-	// Формируем URL для подключения
-	var endpoint url.URL
-	endpoint.Scheme = "http"
-	if strings.HasPrefix(config.Host, "https://") {
-		endpoint.Scheme = "https"
+	endpoint, err := buildEndpoint(config)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient, err := buildHTTPClient(config, endpoint.Scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	// Создаем клиент
+	client, err := transmissionrpc.New(endpoint, &transmissionrpc.Config{
+		CustomClient: httpClient,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transmission client: %w", err)
+	}
+
+	requestTimeout := config.RequestTimeout
+	if requestTimeout <= 0 {
+		requestTimeout = defaultRequestTimeout
 	}
 
-	// Очищаем хост от протокола
-	host := strings.TrimPrefix(config.Host, "http://")
-	host = strings.TrimPrefix(host, "https://")
+	return &TransmissionClient{
+		client:         client,
+		rpc:            newRPCCaller(endpoint, httpClient),
+		requestTimeout: requestTimeout,
+	}, nil
+}
+
+// requestContext ограничивает вызов RPC таймаутом клиента.
+// Отмена родительского контекста прерывает запрос сразу.
+func (c *TransmissionClient) requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, c.requestTimeout)
+}
+
+// getRPCVersion возвращает версию RPC демона, запрашивая ее один раз
+func (c *TransmissionClient) getRPCVersion(ctx context.Context) (int64, error) {
+	c.versionMu.Lock()
+	defer c.versionMu.Unlock()
+
+	if c.rpcVersion > 0 {
+		return c.rpcVersion, nil
+	}
+
+	session, err := c.client.SessionArgumentsGet(ctx, []string{"rpc-version"})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rpc version: %w", err)
+	}
+	if session.RPCVersion == nil {
+		return 0, fmt.Errorf("rpc version not available")
+	}
+
+	c.rpcVersion = *session.RPCVersion
+	return c.rpcVersion, nil
+}
+
+// splitHost разбирает строку хоста на схему, имя хоста и путь
+func splitHost(rawHost string) (scheme string, host string, path string) {
+	host = strings.TrimSpace(rawHost)
+	switch {
+	case strings.HasPrefix(host, "https://"):
+		scheme = schemeHTTPS
+		host = strings.TrimPrefix(host, "https://")
+	case strings.HasPrefix(host, "http://"):
+		scheme = schemeHTTP
+		host = strings.TrimPrefix(host, "http://")
+	}
 
-	// Убираем любой path из хоста, если он есть
 	if idx := strings.Index(host, "/"); idx != -1 {
+		path = host[idx:]
 		host = host[:idx]
 	}
+	return scheme, host, path
+}
+
+// normalizeRPCPath приводит путь к RPC к виду /path/to/rpc
+func normalizeRPCPath(path string) string {
+	path = strings.TrimSpace(path)
+	path = strings.TrimRight(path, "/")
+	if path == "" {
+		return defaultRPCPath
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return path
+}
+
+// buildEndpoint формирует URL для подключения к RPC
+func buildEndpoint(config TransmissionConfig) (*url.URL, error) {
+	hostScheme, host, hostPath := splitHost(config.Host)
+	if host == "" {
+		return nil, &LocalizedError{key: "errors.hostEmpty"}
+	}
+
+	endpoint := &url.URL{Scheme: schemeHTTP}
+	switch {
+	case config.Scheme != "":
+		endpoint.Scheme = strings.ToLower(config.Scheme)
+	case hostScheme != "":
+		endpoint.Scheme = hostScheme
+	}
+
+	// Явно заданный путь имеет приоритет над путем, введенным вместе с хостом
+	rpcPath := config.RPCPath
+	if rpcPath == "" {
+		rpcPath = hostPath
+	}
 
 	endpoint.Host = fmt.Sprintf("%s:%d", host, config.Port)
-	endpoint.Path = "/transmission/rpc"
+	endpoint.Path = normalizeRPCPath(rpcPath)
 
 	// Добавляем учетные данные в URL, если они предоставлены
 	if config.Username != "" {
 		endpoint.User = url.UserPassword(config.Username, config.Password)
 	}
 
-	// Создаем клиент
-	client, err := transmissionrpc.New(&endpoint, &transmissionrpc.Config{})
+	return endpoint, nil
+}
+
+// buildHTTPClient создает HTTP-клиент с учетом настроек TLS
+func buildHTTPClient(config TransmissionConfig, scheme string) (*http.Client, error) {
+	connectTimeout := config.ConnectTimeout
+	if connectTimeout <= 0 {
+		connectTimeout = defaultConnectTimeout
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{
+		Timeout:   connectTimeout,
+		KeepAlive: 30 * time.Second,
+	}).DialContext
+	transport.TLSHandshakeTimeout = connectTimeout
+
+	if scheme == schemeHTTPS {
+		tlsConfig, err := buildTLSConfig(config)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	return &http.Client{Transport: transport}, nil
+}
+
+// buildTLSConfig загружает пользовательские корневые сертификаты и клиентский сертификат
+func buildTLSConfig(config TransmissionConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: config.AllowInsecure,
+	}
+
+	if config.CACertPath != "" {
+		pemData, err := os.ReadFile(config.CACertPath)
+		if err != nil {
+			return nil, &LocalizedError{key: "errors.caCertNotReadable"}
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pemData) {
+			return nil, &LocalizedError{key: "errors.caCertInvalid"}
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if config.ClientCertPath != "" || config.ClientKeyPath != "" {
+		if config.ClientCertPath == "" || config.ClientKeyPath == "" {
+			return nil, &LocalizedError{key: "errors.clientCertIncomplete"}
+		}
+		cert, err := tls.LoadX509KeyPair(config.ClientCertPath, config.ClientKeyPath)
+		if err != nil {
+			return nil, &LocalizedError{key: "errors.clientCertInvalid"}
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// ValidateConfig проверяет параметры подключения без обращения к серверу
+func ValidateConfig(config TransmissionConfig) error {
+	endpoint, err := buildEndpoint(config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create transmission client: %w", err)
+		return err
 	}
 
-	return &TransmissionClient{
-		client: client,
-		ctx:    context.Background(),
-	}, nil
+	if endpoint.Scheme != schemeHTTP && endpoint.Scheme != schemeHTTPS {
+		return &LocalizedError{key: "errors.invalidScheme"}
+	}
+
+	if config.Port <= 0 || config.Port > 65535 {
+		return &LocalizedError{key: "errors.invalidPort"}
+	}
+
+	if strings.ContainsAny(endpoint.Path, "?# ") {
+		return &LocalizedError{key: "errors.invalidRPCPath"}
+	}
+
+	if config.ConnectTimeout < 0 || config.RequestTimeout < 0 {
+		return &LocalizedError{key: "errors.invalidTimeout"}
+	}
+
+	hasTLSOptions := config.CACertPath != "" || config.ClientCertPath != "" ||
+		config.ClientKeyPath != "" || config.AllowInsecure
+	if endpoint.Scheme == schemeHTTP && hasTLSOptions {
+		return &LocalizedError{key: "errors.tlsOptionsRequireHTTPS"}
+	}
+
+	if endpoint.Scheme == schemeHTTPS {
+		if _, err := buildTLSConfig(config); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // validatePath подготавливает и проверяет путь
@@ -72,7 +282,7 @@ func (c *TransmissionClient) validatePath(path string) (string, error) {
 
 	if strings.HasPrefix(path, "~/") {
 		home, err := os.UserHomeDir()
-		if (err != nil) {
+		if err != nil {
 			return "", &LocalizedError{key: "errors.invalidPath"}
 		}
 		path = filepath.Join(home, path[2:])
@@ -82,10 +292,13 @@ func (c *TransmissionClient) validatePath(path string) (string, error) {
 }
 
 // checkAccessibility проверяет доступность пути
-func (c *TransmissionClient) checkAccessibility(path string) error {
+func (c *TransmissionClient) checkAccessibility(ctx context.Context, path string) error {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
 	parentDir := filepath.Dir(path)
 
-	_, _, err := c.client.FreeSpace(c.ctx, parentDir)
+	_, _, err := c.client.FreeSpace(ctx, parentDir)
 	if err != nil {
 		errStr := err.Error()
 		switch {
@@ -101,11 +314,11 @@ func (c *TransmissionClient) checkAccessibility(path string) error {
 }
 
 // ValidateDownloadPath проверяет существование и доступность пути для скачивания
-func (c *TransmissionClient) ValidateDownloadPath(path string) error {
+func (c *TransmissionClient) ValidateDownloadPath(ctx context.Context, path string) error {
 	validPath, err := c.validatePath(path)
 	if err != nil {
 		return err
 	}
 
-	return c.checkAccessibility(validPath)
+	return c.checkAccessibility(ctx, validPath)
 }
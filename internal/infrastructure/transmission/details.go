@@ -0,0 +1,160 @@
+package transmission
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"transmission-client-go/internal/domain"
+
+	"github.com/hekmon/transmissionrpc/v3"
+)
+
+// detailsFields поля, запрашиваемые для подробных сведений о торренте
+var detailsFields = []string{
+	"id", "name", "hashString", "downloadDir", "comment", "creator", "isPrivate",
+	"dateCreated", "addedDate", "doneDate", "totalSize", "pieceCount", "pieceSize",
+	"errorString", "seedRatioMode", "seedRatioLimit", "seedIdleMode", "seedIdleLimit",
+}
+
+// GetTorrentDetails возвращает подробные сведения о торренте, включая условия раздачи
+func (c *TransmissionClient) GetTorrentDetails(ctx context.Context, id int64) (*domain.TorrentDetails, error) {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	torrents, err := c.client.TorrentGet(ctx, detailsFields, []int64{id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get torrent details: %w", err)
+	}
+	if len(torrents) == 0 {
+		return nil, fmt.Errorf("torrent not found")
+	}
+
+	t := torrents[0]
+	details := &domain.TorrentDetails{
+		ID:          id,
+		Name:        valueOf(t.Name),
+		HashString:  valueOf(t.HashString),
+		DownloadDir: valueOf(t.DownloadDir),
+		Comment:     valueOf(t.Comment),
+		Creator:     valueOf(t.Creator),
+		IsPrivate:   valueOf(t.IsPrivate),
+		DateCreated: unixTime(t.DateCreated),
+		AddedDate:   unixTime(t.AddedDate),
+		DoneDate:    unixTime(t.DoneDate),
+		PieceCount:  valueOf(t.PieceCount),
+		Error:       valueOf(t.ErrorString),
+		Seeding:     getSeedingPolicy(&t),
+	}
+	if t.TotalSize != nil {
+		details.TotalSize = int64(t.TotalSize.Byte())
+	}
+	if t.PieceSize != nil {
+		details.PieceSize = int64(t.PieceSize.Byte())
+	}
+	return details, nil
+}
+
+// SetSeedingPolicy задает торрентам собственные условия остановки раздачи
+func (c *TransmissionClient) SetSeedingPolicy(ctx context.Context, ids []int64, policy domain.SeedingPolicy) error {
+	ratioMode, err := toSeedRatioMode(policy.RatioMode)
+	if err != nil {
+		return err
+	}
+	idleMode, err := seedLimitModeValue(policy.IdleMode)
+	if err != nil {
+		return err
+	}
+
+	payload := transmissionrpc.TorrentSetPayload{
+		IDs:           ids,
+		SeedRatioMode: &ratioMode,
+		SeedIdleMode:  &idleMode,
+	}
+
+	if policy.RatioMode == domain.SeedLimitCustom {
+		if policy.RatioLimit <= 0 {
+			return &LocalizedError{key: "errors.invalidSeedRatioLimit"}
+		}
+		payload.SeedRatioLimit = &policy.RatioLimit
+	}
+	if policy.IdleMode == domain.SeedLimitCustom {
+		if policy.IdleLimit <= 0 {
+			return &LocalizedError{key: "errors.invalidSeedIdleLimit"}
+		}
+		idleLimit := time.Duration(policy.IdleLimit) * time.Minute
+		payload.SeedIdleLimit = &idleLimit
+	}
+
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	if err := c.client.TorrentSet(ctx, payload); err != nil {
+		return fmt.Errorf("failed to set seeding policy: %w", err)
+	}
+	return nil
+}
+
+// getSeedingPolicy возвращает условия раздачи торрента
+func getSeedingPolicy(t *transmissionrpc.Torrent) domain.SeedingPolicy {
+	policy := domain.SeedingPolicy{
+		RatioMode:  domain.SeedLimitGlobal,
+		RatioLimit: valueOf(t.SeedRatioLimit),
+		IdleMode:   mapSeedLimitMode(valueOf(t.SeedIdleMode)),
+	}
+	if t.SeedRatioMode != nil {
+		policy.RatioMode = mapSeedLimitMode(int64(*t.SeedRatioMode))
+	}
+	if t.SeedIdleLimit != nil {
+		policy.IdleLimit = int64(*t.SeedIdleLimit / time.Minute)
+	}
+	return policy
+}
+
+// mapSeedLimitMode преобразует режим ограничения раздачи Transmission (0, 1, 2)
+func mapSeedLimitMode(mode int64) domain.SeedLimitMode {
+	switch mode {
+	case 1:
+		return domain.SeedLimitCustom
+	case 2:
+		return domain.SeedLimitUnlimited
+	default:
+		return domain.SeedLimitGlobal
+	}
+}
+
+// seedLimitModeValue преобразует режим ограничения раздачи в значение Transmission
+func seedLimitModeValue(mode domain.SeedLimitMode) (int64, error) {
+	switch mode {
+	case domain.SeedLimitGlobal:
+		return 0, nil
+	case domain.SeedLimitCustom:
+		return 1, nil
+	case domain.SeedLimitUnlimited:
+		return 2, nil
+	default:
+		return 0, &LocalizedError{key: "errors.invalidSeedLimitMode"}
+	}
+}
+
+// toSeedRatioMode преобразует режим ограничения рейтинга в значение Transmission
+func toSeedRatioMode(mode domain.SeedLimitMode) (transmissionrpc.SeedRatioMode, error) {
+	value, err := seedLimitModeValue(mode)
+	return transmissionrpc.SeedRatioMode(value), err
+}
+
+// valueOf возвращает значение указателя или нулевое значение
+func valueOf[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
+
+// unixTime возвращает Unix-время или 0, если время не задано
+func unixTime(t *time.Time) int64 {
+	if t == nil || t.IsZero() || t.Unix() <= 0 {
+		return 0
+	}
+	return t.Unix()
+}
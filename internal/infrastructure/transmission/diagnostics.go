@@ -0,0 +1,31 @@
+package transmission
+
+import (
+	"context"
+	"fmt"
+)
+
+// TestPort проверяет, доступен ли порт для пиров из интернета
+func (c *TransmissionClient) TestPort(ctx context.Context) (bool, error) {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	open, err := c.client.PortTest(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to test peer port: %w", err)
+	}
+	return open, nil
+}
+
+// UpdateBlocklist загружает список блокировки по адресу из настроек сессии
+// и возвращает количество правил в нем
+func (c *TransmissionClient) UpdateBlocklist(ctx context.Context) (int64, error) {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	size, err := c.client.BlocklistUpdate(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update blocklist: %w", err)
+	}
+	return size, nil
+}
@@ -0,0 +1,184 @@
+package transmission
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"transmission-client-go/internal/domain"
+	"transmission-client-go/internal/infrastructure/metainfo"
+
+	"github.com/hekmon/transmissionrpc/v3"
+)
+
+// errDuplicateTorrent результат torrent-add старых версий Transmission для дубликата
+const errDuplicateTorrent = "duplicate torrent"
+
+// localTorrent сведения о добавляемом торренте, известные до отправки демону
+type localTorrent struct {
+	infoHash string
+	trackers []string
+}
+
+// torrentsByHashRequest запрос torrent-get с info-hash вместо числовых ID
+type torrentsByHashRequest struct {
+	Fields []string `json:"fields"`
+	IDs    []string `json:"ids"`
+}
+
+// torrentsResponse ответ torrent-get
+type torrentsResponse struct {
+	Torrents []transmissionrpc.Torrent `json:"torrents"`
+}
+
+// torrentAddResponse ответ torrent-add: заполнено одно из полей
+type torrentAddResponse struct {
+	TorrentAdded     *transmissionrpc.Torrent `json:"torrent-added"`
+	TorrentDuplicate *transmissionrpc.Torrent `json:"torrent-duplicate"`
+}
+
+// parseTorrentURL проверяет magnet-ссылку до отправки демону и извлекает из нее info-hash.
+// Остальные адреса (http, путь на стороне демона) проверяет сам Transmission.
+func parseTorrentURL(url string) (localTorrent, error) {
+	if strings.TrimSpace(url) == "" {
+		return localTorrent{}, &LocalizedError{key: "errors.emptyTorrentUrl"}
+	}
+	if !metainfo.IsMagnet(url) {
+		return localTorrent{}, nil
+	}
+
+	magnet, err := metainfo.ParseMagnet(url)
+	if err != nil {
+		return localTorrent{}, &LocalizedError{key: "errors.invalidMagnet"}
+	}
+	return localTorrent{infoHash: magnet.InfoHash, trackers: magnet.Trackers}, nil
+}
+
+// parseTorrentData извлекает info-hash и трекеры из .torrent файла.
+// Некорректный файл не считается ошибкой: его отклонит сам демон.
+func parseTorrentData(data []byte) localTorrent {
+	preview, err := metainfo.Parse(data)
+	if err != nil {
+		return localTorrent{}
+	}
+	return localTorrent{infoHash: preview.InfoHash, trackers: preview.Trackers}
+}
+
+// addTorrent отправляет torrent-add и различает добавленный торрент и дубликат.
+// Если info-hash известен заранее, дубликат находится без обращения к torrent-add.
+func (c *TransmissionClient) addTorrent(
+	ctx context.Context,
+	payload transmissionrpc.TorrentAddPayload,
+	local localTorrent,
+	mergeTrackers bool,
+) (*domain.AddTorrentResult, error) {
+	if local.infoHash != "" {
+		existing, err := c.findTorrentByHash(ctx, local.infoHash)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return c.duplicateResult(ctx, *existing, local.trackers, mergeTrackers)
+		}
+	}
+
+	var response torrentAddResponse
+	if err := c.rpc.call(ctx, "torrent-add", payload, &response); err != nil {
+		// Старые версии не сообщают, какой торрент уже добавлен
+		if strings.Contains(err.Error(), errDuplicateTorrent) {
+			return &domain.AddTorrentResult{Status: domain.AddStatusDuplicate, InfoHash: local.infoHash}, nil
+		}
+		return nil, err
+	}
+
+	switch {
+	case response.TorrentAdded != nil:
+		return newAddResult(domain.AddStatusAdded, *response.TorrentAdded), nil
+	case response.TorrentDuplicate != nil:
+		return c.duplicateResult(ctx, *response.TorrentDuplicate, local.trackers, mergeTrackers)
+	default:
+		return nil, errors.New("torrent-add returned neither torrent-added nor torrent-duplicate")
+	}
+}
+
+// findTorrentByHash ищет на сервере торрент с указанным info-hash.
+// Transmission принимает info-hash в ids, поэтому весь список не запрашивается.
+func (c *TransmissionClient) findTorrentByHash(ctx context.Context, infoHash string) (*transmissionrpc.Torrent, error) {
+	var response torrentsResponse
+	err := c.rpc.call(ctx, "torrent-get", torrentsByHashRequest{
+		Fields: []string{"id", "name", "hashString"},
+		IDs:    []string{infoHash},
+	}, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for duplicate torrent: %w", err)
+	}
+
+	for _, t := range response.Torrents {
+		if t.HashString != nil && strings.EqualFold(*t.HashString, infoHash) {
+			return &t, nil
+		}
+	}
+	return nil, nil
+}
+
+// duplicateResult формирует результат для уже добавленного торрента
+// и при необходимости переносит в него трекеры нового торрента
+func (c *TransmissionClient) duplicateResult(
+	ctx context.Context,
+	existing transmissionrpc.Torrent,
+	trackers []string,
+	mergeTrackers bool,
+) (*domain.AddTorrentResult, error) {
+	result := newAddResult(domain.AddStatusDuplicate, existing)
+	if !mergeTrackers || len(trackers) == 0 || result.ID == 0 {
+		return result, nil
+	}
+
+	merged, err := c.mergeTrackers(ctx, result.ID, trackers)
+	if err != nil {
+		return nil, err
+	}
+	result.MergedTrackers = merged
+	return result, nil
+}
+
+// mergeTrackers добавляет к торренту трекеры, которых у него еще нет.
+// Некорректные адреса пропускаются. Возвращает количество добавленных трекеров.
+func (c *TransmissionClient) mergeTrackers(ctx context.Context, id int64, trackers []string) (int, error) {
+	current, err := c.GetTorrentTrackers(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	var missing []string
+	for _, announce := range trackers {
+		if ValidateAnnounceURL(announce) != nil || slices.Contains(missing, announce) {
+			continue
+		}
+		exists := slices.ContainsFunc(current, func(t domain.TorrentTracker) bool {
+			return t.Announce == announce
+		})
+		if !exists {
+			missing = append(missing, announce)
+		}
+	}
+
+	if len(missing) == 0 {
+		return 0, nil
+	}
+	if err := c.AddTrackers(ctx, id, missing); err != nil {
+		return 0, err
+	}
+	return len(missing), nil
+}
+
+// newAddResult преобразует ответ torrent-add в доменную модель
+func newAddResult(status domain.AddTorrentStatus, t transmissionrpc.Torrent) *domain.AddTorrentResult {
+	return &domain.AddTorrentResult{
+		Status:   status,
+		ID:       valueOf(t.ID),
+		Name:     valueOf(t.Name),
+		InfoHash: strings.ToLower(valueOf(t.HashString)),
+	}
+}
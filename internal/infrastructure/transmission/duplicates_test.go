@@ -0,0 +1,253 @@
+package transmission
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"transmission-client-go/internal/domain"
+)
+
+const (
+	testInfoHash = "c12fe1c06bba254a9dc9f519b335aa7c1367a88a"
+	testMagnet   = "magnet:?xt=urn:btih:" + testInfoHash + "&dn=Ubuntu"
+)
+
+// rpcHandler отвечает на один метод RPC: результат и аргументы ответа
+type rpcHandler func(args map[string]any) (string, any)
+
+// fakeDaemon минимальный RPC-сервер Transmission, запоминающий вызовы
+type fakeDaemon struct {
+	handlers map[string]rpcHandler
+
+	mu    sync.Mutex
+	calls []rpcCall
+}
+
+type rpcCall struct {
+	method string
+	args   map[string]any
+}
+
+func newFakeClient(t *testing.T, handlers map[string]rpcHandler) (*TransmissionClient, *fakeDaemon) {
+	t.Helper()
+
+	daemon := &fakeDaemon{handlers: handlers}
+	server := httptest.NewServer(daemon)
+	t.Cleanup(server.Close)
+
+	endpoint, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, _ := strconv.Atoi(endpoint.Port())
+	client, err := NewTransmissionClient(TransmissionConfig{Host: endpoint.Hostname(), Port: port})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, daemon
+}
+
+func (d *fakeDaemon) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Method    string         `json:"method"`
+		Arguments map[string]any `json:"arguments"`
+		Tag       *int           `json:"tag,omitempty"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	d.mu.Lock()
+	d.calls = append(d.calls, rpcCall{method: request.Method, args: request.Arguments})
+	d.mu.Unlock()
+
+	result, arguments := "success", any(map[string]any{})
+	if handler, ok := d.handlers[request.Method]; ok {
+		result, arguments = handler(request.Arguments)
+	}
+	_ = json.NewEncoder(w).Encode(map[string]any{"result": result, "arguments": arguments, "tag": request.Tag})
+}
+
+// methodCalls возвращает аргументы всех вызовов метода
+func (d *fakeDaemon) methodCalls(method string) []map[string]any {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var result []map[string]any
+	for _, call := range d.calls {
+		if call.method == method {
+			result = append(result, call.args)
+		}
+	}
+	return result
+}
+
+// hasField проверяет, запрошено ли поле в torrent-get
+func hasField(args map[string]any, field string) bool {
+	fields, _ := args["fields"].([]any)
+	return slices.Contains(fields, any(field))
+}
+
+func torrentsReply(torrents ...map[string]any) (string, any) {
+	if torrents == nil {
+		torrents = []map[string]any{}
+	}
+	return "success", map[string]any{"torrents": torrents}
+}
+
+func TestParseTorrentURL(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		wantHash     string
+		wantTrackers []string
+		wantErr      string
+	}{
+		{name: "empty", url: "  ", wantErr: "errors.emptyTorrentUrl"},
+		{name: "http url is left to the daemon", url: "https://example.com/a.torrent"},
+		{name: "magnet", url: testMagnet + "&tr=udp%3A%2F%2Ft%3A80", wantHash: testInfoHash, wantTrackers: []string{"udp://t:80"}},
+		{name: "invalid magnet", url: "magnet:?xt=urn:btih:abc", wantErr: "errors.invalidMagnet"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			local, err := parseTorrentURL(tt.url)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("parseTorrentURL() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseTorrentURL() error = %v", err)
+			}
+			if local.infoHash != tt.wantHash || !slices.Equal(local.trackers, tt.wantTrackers) {
+				t.Errorf("parseTorrentURL() = %+v", local)
+			}
+		})
+	}
+}
+
+func TestAddDetectsDuplicates(t *testing.T) {
+	existing := map[string]any{"id": 5, "name": "Ubuntu", "hashString": testInfoHash}
+
+	tests := []struct {
+		name       string
+		url        string
+		torrentGet rpcHandler
+		torrentAdd rpcHandler
+		want       domain.AddTorrentResult
+		wantAdd    bool
+	}{
+		{
+			name:       "known info-hash is looked up by hash",
+			url:        testMagnet,
+			torrentGet: func(map[string]any) (string, any) { return torrentsReply(existing) },
+			want:       domain.AddTorrentResult{Status: domain.AddStatusDuplicate, ID: 5, Name: "Ubuntu", InfoHash: testInfoHash},
+		},
+		{
+			name:       "unknown info-hash is added",
+			url:        testMagnet,
+			torrentGet: func(map[string]any) (string, any) { return torrentsReply() },
+			torrentAdd: func(map[string]any) (string, any) {
+				return "success", map[string]any{"torrent-added": existing}
+			},
+			want:    domain.AddTorrentResult{Status: domain.AddStatusAdded, ID: 5, Name: "Ubuntu", InfoHash: testInfoHash},
+			wantAdd: true,
+		},
+		{
+			name: "torrent-duplicate reply",
+			url:  "https://example.com/a.torrent",
+			torrentAdd: func(map[string]any) (string, any) {
+				return "success", map[string]any{"torrent-duplicate": existing}
+			},
+			want:    domain.AddTorrentResult{Status: domain.AddStatusDuplicate, ID: 5, Name: "Ubuntu", InfoHash: testInfoHash},
+			wantAdd: true,
+		},
+		{
+			name:       "legacy duplicate error",
+			url:        "https://example.com/a.torrent",
+			torrentAdd: func(map[string]any) (string, any) { return "duplicate torrent", nil },
+			want:       domain.AddTorrentResult{Status: domain.AddStatusDuplicate},
+			wantAdd:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlers := map[string]rpcHandler{}
+			if tt.torrentGet != nil {
+				handlers["torrent-get"] = tt.torrentGet
+			}
+			if tt.torrentAdd != nil {
+				handlers["torrent-add"] = tt.torrentAdd
+			}
+			client, daemon := newFakeClient(t, handlers)
+
+			result, err := client.Add(context.Background(), tt.url, domain.AddTorrentOptions{})
+			if err != nil {
+				t.Fatalf("Add() error = %v", err)
+			}
+			if *result != tt.want {
+				t.Errorf("Add() = %+v, want %+v", *result, tt.want)
+			}
+
+			if added := len(daemon.methodCalls("torrent-add")) > 0; added != tt.wantAdd {
+				t.Errorf("torrent-add called = %v, want %v", added, tt.wantAdd)
+			}
+			for _, args := range daemon.methodCalls("torrent-get") {
+				if ids, _ := args["ids"].([]any); !slices.Equal(ids, []any{testInfoHash}) {
+					t.Errorf("torrent-get ids = %v, want only the info-hash", args["ids"])
+				}
+			}
+		})
+	}
+}
+
+func TestAddMergesTrackersIntoDuplicate(t *testing.T) {
+	client, daemon := newFakeClient(t, map[string]rpcHandler{
+		"session-get": func(map[string]any) (string, any) {
+			return "success", map[string]any{"rpc-version": trackerListRPCVersion}
+		},
+		"torrent-get": func(args map[string]any) (string, any) {
+			switch {
+			case hasField(args, "trackerStats"):
+				return torrentsReply(map[string]any{"id": 5, "trackerStats": []any{
+					map[string]any{"id": 0, "announce": "http://a/announce", "tier": 0, "lastScrapeTimedOut": false},
+				}})
+			case hasField(args, "trackers"):
+				return torrentsReply(map[string]any{"id": 5, "trackers": []any{
+					map[string]any{"id": 0, "announce": "http://a/announce", "tier": 0},
+				}})
+			default:
+				return torrentsReply(map[string]any{"id": 5, "name": "Ubuntu", "hashString": testInfoHash})
+			}
+		},
+	})
+
+	// Известный трекер и некорректный адрес пропускаются, новый трекер добавляется
+	magnet := testMagnet + "&tr=http%3A%2F%2Fa%2Fannounce&tr=ftp%3A%2F%2Fbad&tr=udp%3A%2F%2Fb%3A80"
+	result, err := client.Add(context.Background(), magnet, domain.AddTorrentOptions{MergeTrackers: true})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if result.Status != domain.AddStatusDuplicate || result.MergedTrackers != 1 {
+		t.Fatalf("Add() = %+v, want duplicate with one merged tracker", *result)
+	}
+
+	sets := daemon.methodCalls("torrent-set")
+	if len(sets) != 1 {
+		t.Fatalf("torrent-set calls = %d, want 1", len(sets))
+	}
+	want := "http://a/announce\n\nudp://b:80"
+	if got := sets[0]["trackerList"]; got != want {
+		t.Errorf("trackerList = %q, want %q", got, want)
+	}
+}
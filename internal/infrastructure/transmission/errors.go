@@ -16,6 +16,11 @@ type LocalizedError struct {
 	key string
 }
 
+// NewLocalizedError создает ошибку с ключом локализации для слоев над клиентом Transmission
+func NewLocalizedError(key string) *LocalizedError {
+	return &LocalizedError{key: key}
+}
+
 func (e *LocalizedError) Error() string {
 	return e.key
 }
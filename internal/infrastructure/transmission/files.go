@@ -1,6 +1,7 @@
 package transmission
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"path/filepath"
@@ -10,12 +11,15 @@ import (
 )
 
 // GetTorrentFiles возвращает список файлов торрента
-func (c *TransmissionClient) GetTorrentFiles(id int64) ([]domain.TorrentFile, error) {
-	torrents, err := c.client.TorrentGet(c.ctx, []string{
+func (c *TransmissionClient) GetTorrentFiles(ctx context.Context, id int64) ([]domain.TorrentFile, error) {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	torrents, err := c.client.TorrentGet(ctx, []string{
 		"files", "fileStats", "name",
 	}, []int64{id})
 
-	if (err != nil) {
+	if err != nil {
 		return nil, fmt.Errorf("failed to get torrent files: %w", err)
 	}
 
@@ -45,12 +49,14 @@ func (c *TransmissionClient) GetTorrentFiles(id int64) ([]domain.TorrentFile, er
 			progress = float64(stats.BytesCompleted) / float64(file.Length) * 100
 		}
 		result[i] = domain.TorrentFile{
-			ID:       i,
-			Name:     filepath.Base(file.Name),
-			Path:     file.Name,
-			Size:     file.Length,
-			Progress: progress,
-			Wanted:   stats.Wanted,
+			ID:        i,
+			Name:      filepath.Base(file.Name),
+			Path:      file.Name,
+			Size:      file.Length,
+			Completed: stats.BytesCompleted,
+			Progress:  progress,
+			Wanted:    stats.Wanted,
+			Priority:  mapFilePriority(stats.Priority),
 		}
 	}
 
@@ -58,7 +64,10 @@ func (c *TransmissionClient) GetTorrentFiles(id int64) ([]domain.TorrentFile, er
 }
 
 // SetFilesWanted устанавливает, нужно ли загружать файлы
-func (c *TransmissionClient) SetFilesWanted(id int64, fileIds []int, wanted bool) error {
+func (c *TransmissionClient) SetFilesWanted(ctx context.Context, id int64, fileIds []int, wanted bool) error {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
 	fileIds64 := make([]int64, len(fileIds))
 	for i, v := range fileIds {
 		fileIds64[i] = int64(v)
@@ -74,7 +83,7 @@ func (c *TransmissionClient) SetFilesWanted(id int64, fileIds []int, wanted bool
 		payload.FilesUnwanted = fileIds64
 	}
 
-	err := c.client.TorrentSet(c.ctx, payload)
+	err := c.client.TorrentSet(ctx, payload)
 	if err != nil {
 		return fmt.Errorf("failed to set files wanted state: %w", err)
 	}
@@ -82,9 +91,57 @@ func (c *TransmissionClient) SetFilesWanted(id int64, fileIds []int, wanted bool
 	return nil
 }
 
+// SetFilesPriority устанавливает приоритет загрузки файлов
+func (c *TransmissionClient) SetFilesPriority(ctx context.Context, id int64, fileIds []int, priority domain.FilePriority) error {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	fileIds64 := make([]int64, len(fileIds))
+	for i, v := range fileIds {
+		fileIds64[i] = int64(v)
+	}
+
+	payload := transmissionrpc.TorrentSetPayload{
+		IDs: []int64{id},
+	}
+
+	switch priority {
+	case domain.FilePriorityHigh:
+		payload.PriorityHigh = fileIds64
+	case domain.FilePriorityNormal:
+		payload.PriorityNormal = fileIds64
+	case domain.FilePriorityLow:
+		payload.PriorityLow = fileIds64
+	default:
+		return &LocalizedError{key: "errors.invalidFilePriority"}
+	}
+
+	err := c.client.TorrentSet(ctx, payload)
+	if err != nil {
+		return fmt.Errorf("failed to set files priority: %w", err)
+	}
+
+	return nil
+}
+
+// mapFilePriority преобразует приоритет файла Transmission (-1, 0, 1)
+func mapFilePriority(priority int64) domain.FilePriority {
+	switch {
+	case priority > 0:
+		return domain.FilePriorityHigh
+	case priority < 0:
+		return domain.FilePriorityLow
+	default:
+		return domain.FilePriorityNormal
+	}
+}
+
 // GetDefaultDownloadDir возвращает каталог загрузки по умолчанию
-func (c *TransmissionClient) GetDefaultDownloadDir() (string, error) {
-	session, err := c.client.SessionArgumentsGet(c.ctx, []string{"download-dir"})
+func (c *TransmissionClient) GetDefaultDownloadDir(ctx context.Context) (string, error) {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	session, err := c.client.SessionArgumentsGet(ctx, []string{"download-dir"})
 	if err != nil {
 		return "", fmt.Errorf("failed to get default download directory: %w", err)
 	}
@@ -97,20 +154,23 @@ func (c *TransmissionClient) GetDefaultDownloadDir() (string, error) {
 }
 
 // GetSessionStats возвращает статистику текущей сессии
-func (c *TransmissionClient) GetSessionStats() (*domain.SessionStats, error) {
-	session, err := c.client.SessionArgumentsGet(c.ctx, []string{"download-dir", "version"})
+func (c *TransmissionClient) GetSessionStats(ctx context.Context) (*domain.SessionStats, error) {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	session, err := c.client.SessionArgumentsGet(ctx, []string{"download-dir", "version", "alt-speed-enabled"})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get session info: %w", err)
 	}
 
-	stats, err := c.client.SessionStats(c.ctx)
+	stats, err := c.client.SessionStats(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get session stats: %w", err)
 	}
 
 	var freeSpace int64 = 0
 	if session.DownloadDir != nil {
-		freeSpaceInfo, _, err := c.client.FreeSpace(c.ctx, *session.DownloadDir)
+		freeSpaceInfo, _, err := c.client.FreeSpace(ctx, *session.DownloadDir)
 		if err != nil {
 			fmt.Printf("failed to get free space: %v\n", err)
 		} else {
@@ -128,6 +188,7 @@ func (c *TransmissionClient) GetSessionStats() (*domain.SessionStats, error) {
 		TotalUploadSpeed:    stats.UploadSpeed,
 		FreeSpace:           freeSpace,
 		TransmissionVersion: version,
+		AltSpeedEnabled:     valueOf(session.AltSpeedEnabled),
 	}, nil
 }
 
@@ -198,7 +259,7 @@ func (c *TransmissionClient) isPathInList(path string, paths []string) bool {
 }
 
 // GetDownloadPaths возвращает список сохраненных путей скачивания
-func (c *TransmissionClient) GetDownloadPaths(config *domain.Config) ([]string, error) {
+func (c *TransmissionClient) GetDownloadPaths(ctx context.Context, config *domain.Config) ([]string, error) {
 	if config == nil {
 		return nil, errors.New(errConfigNotInitialized)
 	}
@@ -206,7 +267,7 @@ func (c *TransmissionClient) GetDownloadPaths(config *domain.Config) ([]string,
 	var result []string
 
 	// Добавляем путь по умолчанию, если он есть
-	defaultDir, err := c.GetDefaultDownloadDir()
+	defaultDir, err := c.GetDefaultDownloadDir(ctx)
 	if err == nil && defaultDir != "" {
 		result = append(result, defaultDir)
 	}
@@ -0,0 +1,133 @@
+package transmission
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hekmon/transmissionrpc/v3"
+)
+
+// SetLabels заменяет метки торрентов
+func (c *TransmissionClient) SetLabels(ctx context.Context, ids []int64, labels []string) error {
+	labels, err := normalizeLabels(labels)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	return c.setLabels(ctx, ids, labels)
+}
+
+// AddLabels добавляет метки к торрентам, сохраняя уже назначенные
+func (c *TransmissionClient) AddLabels(ctx context.Context, ids []int64, labels []string) error {
+	labels, err := normalizeLabels(labels)
+	if err != nil {
+		return err
+	}
+
+	return c.updateLabels(ctx, ids, func(current []string) []string {
+		result := slices.Clone(current)
+		for _, label := range labels {
+			if !containsLabel(result, label) {
+				result = append(result, label)
+			}
+		}
+		return result
+	})
+}
+
+// RemoveLabels снимает метки с торрентов (без учета регистра)
+func (c *TransmissionClient) RemoveLabels(ctx context.Context, ids []int64, labels []string) error {
+	labels, err := normalizeLabels(labels)
+	if err != nil {
+		return err
+	}
+
+	return c.updateLabels(ctx, ids, func(current []string) []string {
+		return slices.DeleteFunc(slices.Clone(current), func(label string) bool {
+			return containsLabel(labels, label)
+		})
+	})
+}
+
+// updateLabels вычисляет новые метки для каждого торрента и отправляет только изменившиеся.
+// Transmission задает метки целиком, поэтому торренты с одинаковым итоговым набором
+// обновляются одним запросом.
+func (c *TransmissionClient) updateLabels(ctx context.Context, ids []int64, update func([]string) []string) error {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	torrents, err := c.client.TorrentGet(ctx, []string{"id", "labels"}, ids)
+	if err != nil {
+		return fmt.Errorf("failed to get torrent labels: %w", err)
+	}
+
+	groups := make(map[string][]int64)
+	sets := make(map[string][]string)
+	for _, t := range torrents {
+		if t.ID == nil {
+			continue
+		}
+		labels := update(t.Labels)
+		if slices.Equal(labels, t.Labels) {
+			continue
+		}
+		key := strings.Join(labels, ",")
+		groups[key] = append(groups[key], *t.ID)
+		sets[key] = labels
+	}
+
+	for key, groupIDs := range groups {
+		if err := c.setLabels(ctx, groupIDs, sets[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setLabels отправляет метки без проверки
+func (c *TransmissionClient) setLabels(ctx context.Context, ids []int64, labels []string) error {
+	// Пустой, но не nil список нужен, чтобы снять все метки
+	if labels == nil {
+		labels = []string{}
+	}
+
+	err := c.client.TorrentSet(ctx, transmissionrpc.TorrentSetPayload{
+		IDs:    ids,
+		Labels: labels,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set labels: %w", err)
+	}
+	return nil
+}
+
+// normalizeLabels убирает пробелы по краям, пустые метки и дубликаты.
+// Запятая в метке недопустима: Transmission хранит метки через запятую.
+func normalizeLabels(labels []string) ([]string, error) {
+	result := make([]string, 0, len(labels))
+	for _, label := range labels {
+		label = strings.TrimSpace(label)
+		if label == "" {
+			continue
+		}
+		if strings.Contains(label, ",") {
+			return nil, &LocalizedError{key: "errors.invalidLabel"}
+		}
+		if !containsLabel(result, label) {
+			result = append(result, label)
+		}
+	}
+	return result, nil
+}
+
+// containsLabel проверяет наличие метки в списке без учета регистра
+func containsLabel(labels []string, label string) bool {
+	return slices.ContainsFunc(labels, func(l string) bool {
+		return strings.EqualFold(l, label)
+	})
+}
@@ -0,0 +1,70 @@
+package transmission
+
+import (
+	"context"
+	"fmt"
+	"transmission-client-go/internal/domain"
+
+	"github.com/hekmon/transmissionrpc/v3"
+)
+
+// GetTorrentPeers возвращает подключенных пиров торрента и источники их получения
+func (c *TransmissionClient) GetTorrentPeers(ctx context.Context, id int64) (*domain.TorrentPeers, error) {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	torrents, err := c.client.TorrentGet(ctx, []string{"id", "peers", "peersFrom"}, []int64{id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get torrent peers: %w", err)
+	}
+	if len(torrents) == 0 {
+		return nil, fmt.Errorf("torrent not found")
+	}
+
+	t := torrents[0]
+	result := &domain.TorrentPeers{
+		Peers: make([]domain.TorrentPeer, len(t.Peers)),
+	}
+	for i, peer := range t.Peers {
+		result.Peers[i] = convertPeer(peer)
+		if result.Peers[i].IsSeed {
+			result.SeedsConnected++
+		}
+	}
+	if t.PeersFrom != nil {
+		result.PeersFrom = domain.PeersFrom{
+			Tracker:  t.PeersFrom.FromTracker,
+			DHT:      t.PeersFrom.FromDHT,
+			PEX:      t.PeersFrom.FromPEX,
+			LPD:      t.PeersFrom.FromLPD,
+			Incoming: t.PeersFrom.FromIncoming,
+			LTEP:     t.PeersFrom.FromLTEP,
+			Cache:    t.PeersFrom.FromCache,
+		}
+	}
+	return result, nil
+}
+
+// convertPeer преобразует пира в доменную модель
+func convertPeer(peer transmissionrpc.Peer) domain.TorrentPeer {
+	return domain.TorrentPeer{
+		Address:           peer.Address,
+		Port:              peer.Port,
+		ClientName:        peer.ClientName,
+		Progress:          peer.Progress * 100,
+		DownloadSpeed:     peer.RateToClient,
+		UploadSpeed:       peer.RateToPeer,
+		Flags:             peer.FlagStr,
+		IsEncrypted:       peer.IsEncrypted,
+		IsIncoming:        peer.IsIncoming,
+		IsUTP:             peer.IsUTP,
+		IsDownloadingFrom: peer.IsDownloadingFrom,
+		IsUploadingTo:     peer.IsUploadingTo,
+		IsSeed:            isSeedPeer(peer),
+	}
+}
+
+// isSeedPeer определяет, есть ли у пира все данные торрента
+func isSeedPeer(peer transmissionrpc.Peer) bool {
+	return peer.Progress >= 1
+}
@@ -0,0 +1,128 @@
+package transmission
+
+import (
+	"context"
+	"fmt"
+	"transmission-client-go/internal/domain"
+
+	"github.com/hekmon/transmissionrpc/v3"
+)
+
+// queueSettingsFields поля сессии, относящиеся к очередям
+var queueSettingsFields = []string{
+	"download-queue-enabled", "download-queue-size",
+	"seed-queue-enabled", "seed-queue-size",
+	"queue-stalled-enabled", "queue-stalled-minutes",
+}
+
+// QueueMoveTop перемещает торренты в начало очереди
+func (c *TransmissionClient) QueueMoveTop(ctx context.Context, ids []int64) error {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	if err := c.client.QueueMoveTop(ctx, ids); err != nil {
+		return fmt.Errorf("failed to move torrents to queue top: %w", err)
+	}
+	return nil
+}
+
+// QueueMoveUp перемещает торренты на одну позицию вверх в очереди
+func (c *TransmissionClient) QueueMoveUp(ctx context.Context, ids []int64) error {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	if err := c.client.QueueMoveUp(ctx, ids); err != nil {
+		return fmt.Errorf("failed to move torrents up in queue: %w", err)
+	}
+	return nil
+}
+
+// QueueMoveDown перемещает торренты на одну позицию вниз в очереди
+func (c *TransmissionClient) QueueMoveDown(ctx context.Context, ids []int64) error {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	if err := c.client.QueueMoveDown(ctx, ids); err != nil {
+		return fmt.Errorf("failed to move torrents down in queue: %w", err)
+	}
+	return nil
+}
+
+// QueueMoveBottom перемещает торренты в конец очереди
+func (c *TransmissionClient) QueueMoveBottom(ctx context.Context, ids []int64) error {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	if err := c.client.QueueMoveBottom(ctx, ids); err != nil {
+		return fmt.Errorf("failed to move torrents to queue bottom: %w", err)
+	}
+	return nil
+}
+
+// StartNow запускает торренты в обход очереди
+func (c *TransmissionClient) StartNow(ctx context.Context, ids []int64) error {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	if err := c.client.TorrentStartNowIDs(ctx, ids); err != nil {
+		return fmt.Errorf("failed to start torrents now: %w", err)
+	}
+	return nil
+}
+
+// GetQueueSettings возвращает настройки очередей сессии
+func (c *TransmissionClient) GetQueueSettings(ctx context.Context) (*domain.QueueSettings, error) {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	session, err := c.client.SessionArgumentsGet(ctx, queueSettingsFields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get queue settings: %w", err)
+	}
+
+	settings := getQueueSettings(session)
+	return &settings, nil
+}
+
+// SetQueueSettings сохраняет настройки очередей сессии
+func (c *TransmissionClient) SetQueueSettings(ctx context.Context, settings domain.QueueSettings) error {
+	if err := validateQueueSettings(settings); err != nil {
+		return err
+	}
+
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	err := c.client.SessionArgumentsSet(ctx, transmissionrpc.SessionArguments{
+		DownloadQueueEnabled: &settings.DownloadQueueEnabled,
+		DownloadQueueSize:    &settings.DownloadQueueSize,
+		SeedQueueEnabled:     &settings.SeedQueueEnabled,
+		SeedQueueSize:        &settings.SeedQueueSize,
+		QueueStalledEnabled:  &settings.QueueStalledEnabled,
+		QueueStalledMinutes:  &settings.QueueStalledMinutes,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set queue settings: %w", err)
+	}
+	return nil
+}
+
+// getQueueSettings извлекает настройки очередей из аргументов сессии
+func getQueueSettings(session transmissionrpc.SessionArguments) domain.QueueSettings {
+	return domain.QueueSettings{
+		DownloadQueueEnabled: valueOf(session.DownloadQueueEnabled),
+		DownloadQueueSize:    valueOf(session.DownloadQueueSize),
+		SeedQueueEnabled:     valueOf(session.SeedQueueEnabled),
+		SeedQueueSize:        valueOf(session.SeedQueueSize),
+		QueueStalledEnabled:  valueOf(session.QueueStalledEnabled),
+		QueueStalledMinutes:  valueOf(session.QueueStalledMinutes),
+	}
+}
+
+// validateQueueSettings проверяет размеры очередей
+func validateQueueSettings(settings domain.QueueSettings) error {
+	if settings.DownloadQueueSize < 0 || settings.SeedQueueSize < 0 || settings.QueueStalledMinutes < 0 {
+		return &LocalizedError{key: "errors.invalidSessionLimit"}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package transmission
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"transmission-client-go/internal/domain"
+)
+
+// RenameTorrentPath переименовывает файл или папку внутри торрента
+// и возвращает обновленный список файлов
+func (c *TransmissionClient) RenameTorrentPath(ctx context.Context, id int64, oldPath string, newName string) ([]domain.TorrentFile, error) {
+	if err := validateFileName(newName); err != nil {
+		return nil, err
+	}
+
+	files, err := c.GetTorrentFiles(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	oldPath = strings.Trim(oldPath, "/")
+	if !torrentPathExists(files, oldPath) {
+		return nil, &LocalizedError{key: "errors.renamePathNotFound"}
+	}
+
+	newPath := path.Join(path.Dir(oldPath), newName)
+	if newPath == oldPath {
+		return files, nil
+	}
+	if torrentPathExists(files, newPath) {
+		return nil, &LocalizedError{key: "errors.renameConflict"}
+	}
+
+	reqCtx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	err = c.client.TorrentRenamePath(reqCtx, id, oldPath, newName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rename torrent path: %w", err)
+	}
+
+	return c.GetTorrentFiles(ctx, id)
+}
+
+// validateFileName проверяет, что новое имя является одним элементом пути
+func validateFileName(name string) error {
+	if strings.TrimSpace(name) == "" || name == "." || name == ".." ||
+		strings.ContainsAny(name, `/\`) {
+		return &LocalizedError{key: "errors.invalidFileName"}
+	}
+	return nil
+}
+
+// torrentPathExists проверяет, есть ли в торренте файл или папка с указанным путем
+func torrentPathExists(files []domain.TorrentFile, p string) bool {
+	for _, file := range files {
+		if file.Path == p || strings.HasPrefix(file.Path, p+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+package transmission
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+const (
+	csrfHeader           = "X-Transmission-Session-Id"
+	rpcResultSuccess     = "success"
+	recentlyActiveIDs    = "recently-active"
+	errCSRFRetryExceeded = "CSRF token invalid 2 times in a row"
+)
+
+// rpcCaller выполняет запросы RPC, которые библиотека transmissionrpc не поддерживает
+// (особые значения ids, поля ответа вроде "removed" или "torrent-duplicate")
+type rpcCaller struct {
+	endpoint string
+	http     *http.Client
+
+	mu        sync.RWMutex
+	sessionID string
+}
+
+type rpcRequest struct {
+	Method    string `json:"method"`
+	Arguments any    `json:"arguments,omitempty"`
+}
+
+type rpcResponse struct {
+	Arguments json.RawMessage `json:"arguments"`
+	Result    string          `json:"result"`
+}
+
+func newRPCCaller(endpoint *url.URL, httpClient *http.Client) *rpcCaller {
+	return &rpcCaller{
+		endpoint: endpoint.String(),
+		http:     httpClient,
+	}
+}
+
+// call отправляет запрос и декодирует поле arguments ответа в result
+func (r *rpcCaller) call(ctx context.Context, method string, arguments any, result any) error {
+	return r.do(ctx, method, arguments, result, true)
+}
+
+func (r *rpcCaller) do(ctx context.Context, method string, arguments any, result any, retry bool) error {
+	body, err := json.Marshal(rpcRequest{Method: method, Arguments: arguments})
+	if err != nil {
+		return fmt.Errorf("failed to marshal '%s' request: %w", method, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to prepare '%s' request: %w", method, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	r.mu.RLock()
+	req.Header.Set(csrfHeader, r.sessionID)
+	r.mu.RUnlock()
+
+	resp, err := r.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute '%s' request: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	// Transmission требует повторить запрос с новым идентификатором сессии
+	if resp.StatusCode == http.StatusConflict {
+		r.mu.Lock()
+		r.sessionID = resp.Header.Get(csrfHeader)
+		r.mu.Unlock()
+		if retry {
+			return r.do(ctx, method, arguments, result, false)
+		}
+		return errors.New(errCSRFRetryExceeded)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("'%s' request failed: HTTP %d", method, resp.StatusCode)
+	}
+
+	var answer rpcResponse
+	if err := json.NewDecoder(resp.Body).Decode(&answer); err != nil {
+		return fmt.Errorf("failed to decode '%s' response: %w", method, err)
+	}
+	if answer.Result != rpcResultSuccess {
+		return fmt.Errorf("'%s' rpc method failed: %s", method, answer.Result)
+	}
+
+	if result != nil && len(answer.Arguments) > 0 {
+		if err := json.Unmarshal(answer.Arguments, result); err != nil {
+			return fmt.Errorf("failed to decode '%s' arguments: %w", method, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+package transmission
+
+import (
+	"context"
+	"fmt"
+	"transmission-client-go/internal/domain"
+
+	"github.com/hekmon/transmissionrpc/v3"
+)
+
+const minutesPerDay = 24 * 60
+
+// speedSettingsFields поля сессии, относящиеся к ограничениям скорости
+var speedSettingsFields = []string{
+	"speed-limit-down", "speed-limit-down-enabled", "speed-limit-up", "speed-limit-up-enabled",
+	"alt-speed-down", "alt-speed-up", "alt-speed-enabled",
+	"alt-speed-time-enabled", "alt-speed-time-begin", "alt-speed-time-end", "alt-speed-time-day",
+}
+
+// GetSpeedSettings возвращает ограничения скорости сессии и расписание альтернативной скорости
+func (c *TransmissionClient) GetSpeedSettings(ctx context.Context) (*domain.SpeedSettings, error) {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	session, err := c.client.SessionArgumentsGet(ctx, speedSettingsFields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get speed settings: %w", err)
+	}
+
+	return &domain.SpeedSettings{
+		SpeedLimitDown:        valueOf(session.SpeedLimitDown),
+		SpeedLimitDownEnabled: valueOf(session.SpeedLimitDownEnabled),
+		SpeedLimitUp:          valueOf(session.SpeedLimitUp),
+		SpeedLimitUpEnabled:   valueOf(session.SpeedLimitUpEnabled),
+		AltSpeedDown:          valueOf(session.AltSpeedDown),
+		AltSpeedUp:            valueOf(session.AltSpeedUp),
+		AltSpeedEnabled:       valueOf(session.AltSpeedEnabled),
+		AltSpeedTimeEnabled:   valueOf(session.AltSpeedTimeEnabled),
+		AltSpeedTimeBegin:     valueOf(session.AltSpeedTimeBegin),
+		AltSpeedTimeEnd:       valueOf(session.AltSpeedTimeEnd),
+		AltSpeedTimeDay:       valueOf(session.AltSpeedTimeDay),
+	}, nil
+}
+
+// SetSpeedSettings сохраняет ограничения скорости сессии и расписание альтернативной скорости
+func (c *TransmissionClient) SetSpeedSettings(ctx context.Context, settings domain.SpeedSettings) error {
+	if settings.SpeedLimitDown < 0 || settings.SpeedLimitUp < 0 ||
+		settings.AltSpeedDown < 0 || settings.AltSpeedUp < 0 {
+		return &LocalizedError{key: "errors.invalidSpeedLimit"}
+	}
+	if settings.AltSpeedTimeBegin < 0 || settings.AltSpeedTimeBegin >= minutesPerDay ||
+		settings.AltSpeedTimeEnd < 0 || settings.AltSpeedTimeEnd >= minutesPerDay ||
+		settings.AltSpeedTimeDay < 0 || settings.AltSpeedTimeDay > domain.ScheduleAllDays {
+		return &LocalizedError{key: "errors.invalidSpeedSchedule"}
+	}
+
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	err := c.client.SessionArgumentsSet(ctx, transmissionrpc.SessionArguments{
+		SpeedLimitDown:        &settings.SpeedLimitDown,
+		SpeedLimitDownEnabled: &settings.SpeedLimitDownEnabled,
+		SpeedLimitUp:          &settings.SpeedLimitUp,
+		SpeedLimitUpEnabled:   &settings.SpeedLimitUpEnabled,
+		AltSpeedDown:          &settings.AltSpeedDown,
+		AltSpeedUp:            &settings.AltSpeedUp,
+		AltSpeedEnabled:       &settings.AltSpeedEnabled,
+		AltSpeedTimeEnabled:   &settings.AltSpeedTimeEnabled,
+		AltSpeedTimeBegin:     &settings.AltSpeedTimeBegin,
+		AltSpeedTimeEnd:       &settings.AltSpeedTimeEnd,
+		AltSpeedTimeDay:       &settings.AltSpeedTimeDay,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set speed settings: %w", err)
+	}
+	return nil
+}
+
+// SetAltSpeedEnabled включает или выключает альтернативные ограничения скорости
+func (c *TransmissionClient) SetAltSpeedEnabled(ctx context.Context, enabled bool) error {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	err := c.client.SessionArgumentsSet(ctx, transmissionrpc.SessionArguments{
+		AltSpeedEnabled: &enabled,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to toggle alternative speed: %w", err)
+	}
+	return nil
+}
+
+// sessionSettingsFields поля сессии, доступные в редакторе настроек
+var sessionSettingsFields = []string{
+	"peer-port", "peer-port-random-on-start", "port-forwarding-enabled", "encryption",
+	"dht-enabled", "pex-enabled", "lpd-enabled", "utp-enabled",
+	"peer-limit-global", "peer-limit-per-torrent",
+	"blocklist-enabled", "blocklist-url", "blocklist-size",
+	"download-queue-enabled", "download-queue-size", "seed-queue-enabled", "seed-queue-size",
+	"queue-stalled-enabled", "queue-stalled-minutes",
+	"incomplete-dir-enabled", "incomplete-dir", "rename-partial-files",
+	"start-added-torrents", "trash-original-torrent-files", "cache-size-mb",
+	"script-torrent-added-enabled", "script-torrent-added-filename",
+	"script-torrent-done-enabled", "script-torrent-done-filename",
+	"script-torrent-done-seeding-enabled", "script-torrent-done-seeding-filename",
+}
+
+// GetSessionSettings возвращает настройки демона
+func (c *TransmissionClient) GetSessionSettings(ctx context.Context) (*domain.SessionSettings, error) {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	session, err := c.client.SessionArgumentsGet(ctx, sessionSettingsFields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session settings: %w", err)
+	}
+
+	settings := &domain.SessionSettings{
+		PeerPort:                         valueOf(session.PeerPort),
+		PeerPortRandomOnStart:            valueOf(session.PeerPortRandomOnStart),
+		PortForwardingEnabled:            valueOf(session.PortForwardingEnabled),
+		DHTEnabled:                       valueOf(session.DHTEnabled),
+		PEXEnabled:                       valueOf(session.PEXEnabled),
+		LPDEnabled:                       valueOf(session.LPDEnabled),
+		UTPEnabled:                       valueOf(session.UTPEnabled),
+		PeerLimitGlobal:                  valueOf(session.PeerLimitGlobal),
+		PeerLimitPerTorrent:              valueOf(session.PeerLimitPerTorrent),
+		BlocklistEnabled:                 valueOf(session.BlocklistEnabled),
+		BlocklistURL:                     valueOf(session.BlocklistURL),
+		BlocklistSize:                    valueOf(session.BlocklistSize),
+		QueueSettings:                    getQueueSettings(session),
+		IncompleteDirEnabled:             valueOf(session.IncompleteDirEnabled),
+		IncompleteDir:                    valueOf(session.IncompleteDir),
+		RenamePartialFiles:               valueOf(session.RenamePartialFiles),
+		StartAddedTorrents:               valueOf(session.StartAddedTorrents),
+		TrashOriginalTorrentFiles:        valueOf(session.TrashOriginalTorrentFiles),
+		CacheSizeMB:                      valueOf(session.CacheSizeMB),
+		ScriptTorrentAddedEnabled:        valueOf(session.ScriptTorrentAddedEnabled),
+		ScriptTorrentAddedFilename:       valueOf(session.ScriptTorrentAddedFilename),
+		ScriptTorrentDoneEnabled:         valueOf(session.ScriptTorrentDoneEnabled),
+		ScriptTorrentDoneFilename:        valueOf(session.ScriptTorrentDoneFilename),
+		ScriptTorrentDoneSeedingEnabled:  valueOf(session.ScriptTorrentDoneSeedingEnabled),
+		ScriptTorrentDoneSeedingFilename: valueOf(session.ScriptTorrentDoneSeedingFilename),
+	}
+	if session.Encryption != nil {
+		settings.Encryption = domain.EncryptionMode(*session.Encryption)
+	}
+	return settings, nil
+}
+
+// UpdateSessionSettings сохраняет настройки демона, отправляя только изменившиеся поля
+func (c *TransmissionClient) UpdateSessionSettings(ctx context.Context, settings domain.SessionSettings) error {
+	if err := validateSessionSettings(settings); err != nil {
+		return err
+	}
+
+	current, err := c.GetSessionSettings(ctx)
+	if err != nil {
+		return err
+	}
+
+	var changed bool
+	args := transmissionrpc.SessionArguments{
+		PeerPort:                         changedValue(&changed, current.PeerPort, settings.PeerPort),
+		PeerPortRandomOnStart:            changedValue(&changed, current.PeerPortRandomOnStart, settings.PeerPortRandomOnStart),
+		PortForwardingEnabled:            changedValue(&changed, current.PortForwardingEnabled, settings.PortForwardingEnabled),
+		DHTEnabled:                       changedValue(&changed, current.DHTEnabled, settings.DHTEnabled),
+		PEXEnabled:                       changedValue(&changed, current.PEXEnabled, settings.PEXEnabled),
+		LPDEnabled:                       changedValue(&changed, current.LPDEnabled, settings.LPDEnabled),
+		UTPEnabled:                       changedValue(&changed, current.UTPEnabled, settings.UTPEnabled),
+		PeerLimitGlobal:                  changedValue(&changed, current.PeerLimitGlobal, settings.PeerLimitGlobal),
+		PeerLimitPerTorrent:              changedValue(&changed, current.PeerLimitPerTorrent, settings.PeerLimitPerTorrent),
+		BlocklistEnabled:                 changedValue(&changed, current.BlocklistEnabled, settings.BlocklistEnabled),
+		BlocklistURL:                     changedValue(&changed, current.BlocklistURL, settings.BlocklistURL),
+		DownloadQueueEnabled:             changedValue(&changed, current.DownloadQueueEnabled, settings.DownloadQueueEnabled),
+		DownloadQueueSize:                changedValue(&changed, current.DownloadQueueSize, settings.DownloadQueueSize),
+		SeedQueueEnabled:                 changedValue(&changed, current.SeedQueueEnabled, settings.SeedQueueEnabled),
+		SeedQueueSize:                    changedValue(&changed, current.SeedQueueSize, settings.SeedQueueSize),
+		QueueStalledEnabled:              changedValue(&changed, current.QueueStalledEnabled, settings.QueueStalledEnabled),
+		QueueStalledMinutes:              changedValue(&changed, current.QueueStalledMinutes, settings.QueueStalledMinutes),
+		IncompleteDirEnabled:             changedValue(&changed, current.IncompleteDirEnabled, settings.IncompleteDirEnabled),
+		IncompleteDir:                    changedValue(&changed, current.IncompleteDir, settings.IncompleteDir),
+		RenamePartialFiles:               changedValue(&changed, current.RenamePartialFiles, settings.RenamePartialFiles),
+		StartAddedTorrents:               changedValue(&changed, current.StartAddedTorrents, settings.StartAddedTorrents),
+		TrashOriginalTorrentFiles:        changedValue(&changed, current.TrashOriginalTorrentFiles, settings.TrashOriginalTorrentFiles),
+		CacheSizeMB:                      changedValue(&changed, current.CacheSizeMB, settings.CacheSizeMB),
+		ScriptTorrentAddedEnabled:        changedValue(&changed, current.ScriptTorrentAddedEnabled, settings.ScriptTorrentAddedEnabled),
+		ScriptTorrentAddedFilename:       changedValue(&changed, current.ScriptTorrentAddedFilename, settings.ScriptTorrentAddedFilename),
+		ScriptTorrentDoneEnabled:         changedValue(&changed, current.ScriptTorrentDoneEnabled, settings.ScriptTorrentDoneEnabled),
+		ScriptTorrentDoneFilename:        changedValue(&changed, current.ScriptTorrentDoneFilename, settings.ScriptTorrentDoneFilename),
+		ScriptTorrentDoneSeedingEnabled:  changedValue(&changed, current.ScriptTorrentDoneSeedingEnabled, settings.ScriptTorrentDoneSeedingEnabled),
+		ScriptTorrentDoneSeedingFilename: changedValue(&changed, current.ScriptTorrentDoneSeedingFilename, settings.ScriptTorrentDoneSeedingFilename),
+	}
+	if encryption := changedValue(&changed, current.Encryption, settings.Encryption); encryption != nil {
+		mode := transmissionrpc.Encryption(*encryption)
+		args.Encryption = &mode
+	}
+
+	if !changed {
+		return nil
+	}
+
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	if err := c.client.SessionArgumentsSet(ctx, args); err != nil {
+		return fmt.Errorf("failed to update session settings: %w", err)
+	}
+	return nil
+}
+
+// validateSessionSettings проверяет значения настроек перед отправкой демону
+func validateSessionSettings(settings domain.SessionSettings) error {
+	if settings.PeerPort < 1 || settings.PeerPort > 65535 {
+		return &LocalizedError{key: "errors.invalidPeerPort"}
+	}
+
+	switch settings.Encryption {
+	case domain.EncryptionRequired, domain.EncryptionPreferred, domain.EncryptionTolerated:
+	default:
+		return &LocalizedError{key: "errors.invalidEncryptionMode"}
+	}
+
+	if settings.PeerLimitGlobal < 0 || settings.PeerLimitPerTorrent < 0 || settings.CacheSizeMB < 0 {
+		return &LocalizedError{key: "errors.invalidSessionLimit"}
+	}
+	return validateQueueSettings(settings.QueueSettings)
+}
+
+// changedValue возвращает указатель на новое значение, если оно отличается от текущего,
+// и отмечает, что есть изменения
+func changedValue[T comparable](changed *bool, current T, updated T) *T {
+	if current == updated {
+		return nil
+	}
+	*changed = true
+	return &updated
+}
@@ -1,202 +1,340 @@
 package transmission
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 	"transmission-client-go/internal/domain"
 
 	"github.com/hekmon/transmissionrpc/v3"
 )
 
+// torrentFields поля, запрашиваемые для списка торрентов
+var torrentFields = []string{
+	"id", "name", "downloadDir", "status", "percentDone",
+	"uploadRatio", "peersConnected", "peersSendingToUs", "trackerStats", "uploadedEver",
+	"leftUntilDone", "desiredAvailable", "haveValid", "sizeWhenDone",
+	"rateDownload", "rateUpload", "downloadedEver",
+	"downloadLimit", "uploadLimit", "downloadLimited", "uploadLimited",
+	"recheckProgress", // Добавляем поле для отслеживания прогресса проверки
+	"labels", "addedDate", "isStalled", "queuePosition",
+}
+
 // GetAll возвращает список всех торрентов
-func (c *TransmissionClient) GetAll() ([]domain.Torrent, error) {
-	torrents, err := c.client.TorrentGet(c.ctx, []string{
-		"id", "name", "status", "percentDone",
-		"uploadRatio", "peersConnected", "trackerStats", "uploadedEver",
-		"leftUntilDone", "desiredAvailable", "haveValid", "sizeWhenDone",
-		"rateDownload", "rateUpload", "downloadedEver",
-		"downloadLimit", "uploadLimit", "downloadLimited", "uploadLimited",
-		"recheckProgress", // Добавляем поле для отслеживания прогресса проверки
-	}, nil)
+func (c *TransmissionClient) GetAll(ctx context.Context) ([]domain.Torrent, error) {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	torrents, err := c.client.TorrentGet(ctx, torrentFields, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get torrents: %w", err)
 	}
 
+	return convertTorrents(torrents), nil
+}
+
+// recentlyActiveRequest запрос torrent-get для недавно изменившихся торрентов
+type recentlyActiveRequest struct {
+	Fields []string `json:"fields"`
+	IDs    string   `json:"ids"`
+}
+
+// recentlyActiveResponse ответ torrent-get со списком удаленных торрентов
+type recentlyActiveResponse struct {
+	Torrents []transmissionrpc.Torrent `json:"torrents"`
+	Removed  []int64                   `json:"removed"`
+}
+
+// GetRecentlyActive возвращает торренты, изменившиеся за последнюю минуту, и ID удаленных торрентов
+func (c *TransmissionClient) GetRecentlyActive(ctx context.Context) ([]domain.Torrent, []int64, error) {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	var response recentlyActiveResponse
+	err := c.rpc.call(ctx, "torrent-get", recentlyActiveRequest{
+		Fields: torrentFields,
+		IDs:    recentlyActiveIDs,
+	}, &response)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get recently active torrents: %w", err)
+	}
+
+	return convertTorrents(response.Torrents), response.Removed, nil
+}
+
+// convertTorrents преобразует торренты transmissionrpc в доменную модель
+func convertTorrents(torrents []transmissionrpc.Torrent) []domain.Torrent {
 	result := make([]domain.Torrent, len(torrents))
 	for i, t := range torrents {
-		status := mapStatus(*t.Status, t)
-		totalSize, downloadedSize := getTorrentSizes(t)
-		uploadRatio, uploadedBytes := getUploadInfo(&t)
-		downloadSpeed, uploadSpeed := getSpeedInfo(&t)
-
-		// Расчет прогресса в зависимости от статуса
-		var progress float64
-		if status == domain.StatusChecking && t.RecheckProgress != nil {
-			progress = *t.RecheckProgress * 100
-		} else {
-			progress = *t.PercentDone * 100
-		}
+		result[i] = convertTorrent(t)
+	}
+	return result
+}
 
-		var sizeFormatted string
-		if status == domain.StatusDownloading {
-			sizeFormatted = fmt.Sprintf("%s / %s",
-				formatBytes(downloadedSize, true),
-				formatBytes(totalSize, true))
-		} else {
-			sizeFormatted = formatBytes(totalSize, true)
-		}
+// convertTorrent преобразует один торрент transmissionrpc в доменную модель
+func convertTorrent(t transmissionrpc.Torrent) domain.Torrent {
+	status := mapStatus(*t.Status, t)
+	totalSize, downloadedSize := getTorrentSizes(t)
+	uploadRatio, uploadedBytes := getUploadInfo(&t)
+	downloadSpeed, uploadSpeed := getSpeedInfo(&t)
+
+	// Расчет прогресса в зависимости от статуса
+	var progress float64
+	if status == domain.StatusChecking && t.RecheckProgress != nil {
+		progress = *t.RecheckProgress * 100
+	} else {
+		progress = *t.PercentDone * 100
+	}
 
-		downloadSpeedFormatted := formatBytes(uint64(downloadSpeed), true) + "/s"
-		uploadSpeedFormatted := formatBytes(uint64(uploadSpeed), true) + "/s"
-		uploadedFormatted := formatBytes(uint64(uploadedBytes), true)
+	var sizeFormatted string
+	if status == domain.StatusDownloading {
+		sizeFormatted = fmt.Sprintf("%s / %s",
+			formatBytes(downloadedSize, true),
+			formatBytes(totalSize, true))
+	} else {
+		sizeFormatted = formatBytes(totalSize, true)
+	}
 
-		peersConnected, seedsTotal, peersTotal := getPeerInfo(&t)
+	downloadSpeedFormatted := formatBytes(uint64(downloadSpeed), true) + "/s"
+	uploadSpeedFormatted := formatBytes(uint64(uploadSpeed), true) + "/s"
+	uploadedFormatted := formatBytes(uint64(uploadedBytes), true)
 
-		isSlowMode := false
-		if status == domain.StatusDownloading || status == domain.StatusSeeding {
-			if (t.DownloadLimited != nil && *t.DownloadLimited) ||
-				(t.UploadLimited != nil && *t.UploadLimited) {
-				isSlowMode = true
-			}
-		}
+	peersConnected, peersSendingToUs, seedsTotal, peersTotal := getPeerInfo(&t)
 
-		result[i] = domain.Torrent{
-			ID:                     *t.ID,
-			Name:                   *t.Name,
-			Status:                 status,
-			Progress:               progress,
-			Size:                   int64(totalSize),
-			SizeFormatted:          sizeFormatted,
-			UploadRatio:            uploadRatio,
-			SeedsConnected:         peersConnected,
-			SeedsTotal:             seedsTotal,
-			PeersConnected:         peersConnected,
-			PeersTotal:             peersTotal,
-			UploadedBytes:          uploadedBytes,
-			UploadedFormatted:      uploadedFormatted,
-			DownloadSpeed:          downloadSpeed,
-			UploadSpeed:            uploadSpeed,
-			DownloadSpeedFormatted: downloadSpeedFormatted,
-			UploadSpeedFormatted:   uploadSpeedFormatted,
-			IsSlowMode:             isSlowMode,
+	var downloadDir string
+	if t.DownloadDir != nil {
+		downloadDir = *t.DownloadDir
+	}
+
+	isSlowMode := false
+	if status == domain.StatusDownloading || status == domain.StatusSeeding {
+		if (t.DownloadLimited != nil && *t.DownloadLimited) ||
+			(t.UploadLimited != nil && *t.UploadLimited) {
+			isSlowMode = true
 		}
 	}
 
-	return result, nil
+	return domain.Torrent{
+		ID:                     *t.ID,
+		Name:                   *t.Name,
+		DownloadDir:            downloadDir,
+		Status:                 status,
+		Progress:               progress,
+		Size:                   int64(totalSize),
+		SizeFormatted:          sizeFormatted,
+		UploadRatio:            uploadRatio,
+		PeersSendingToUs:       peersSendingToUs,
+		SeedsTotal:             seedsTotal,
+		PeersConnected:         peersConnected,
+		PeersTotal:             peersTotal,
+		UploadedBytes:          uploadedBytes,
+		UploadedFormatted:      uploadedFormatted,
+		DownloadSpeed:          downloadSpeed,
+		UploadSpeed:            uploadSpeed,
+		DownloadSpeedFormatted: downloadSpeedFormatted,
+		UploadSpeedFormatted:   uploadSpeedFormatted,
+		IsSlowMode:             isSlowMode,
+		Trackers:               getTrackers(&t),
+		Labels:                 t.Labels,
+		AddedDate:              unixTime(t.AddedDate),
+		IsStalled:              valueOf(t.IsStalled),
+		QueuePosition:          valueOf(t.QueuePosition),
+	}
 }
 
 // Add добавляет новый торрент по URL или магнет-ссылке
-func (c *TransmissionClient) Add(url string, downloadDir string) error {
-	if downloadDir != "" {
-		if err := c.ValidateDownloadPath(downloadDir); err != nil {
-			return fmt.Errorf("invalid download directory: %w", err)
-		}
+func (c *TransmissionClient) Add(ctx context.Context, url string, options domain.AddTorrentOptions) (*domain.AddTorrentResult, error) {
+	payload, err := newAddPayload(options)
+	if err != nil {
+		return nil, err
 	}
 
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
 	if strings.HasPrefix(url, "data:") {
-		return c.addFromBase64(url, downloadDir)
+		return c.addFromBase64(ctx, url, payload, options.MergeTrackers)
 	}
-
-	payload := transmissionrpc.TorrentAddPayload{
-		Filename: &url,
+	local, err := parseTorrentURL(url)
+	if err != nil {
+		return nil, err
 	}
 
-	if downloadDir != "" {
-		payload.DownloadDir = &downloadDir
-	}
+	payload.Filename = &url
 
-	_, err := c.client.TorrentAdd(c.ctx, payload)
+	result, err := c.addTorrent(ctx, payload, local, options.MergeTrackers)
 	if err != nil {
 		errStr := err.Error()
 		switch {
 		case strings.Contains(errStr, errPermissionDenied):
-			return fmt.Errorf(errPermissionDeniedForPath, downloadDir)
+			return nil, fmt.Errorf(errPermissionDeniedForPath, options.DownloadDir)
 		case strings.Contains(errStr, errNoSuchFileOrDirectory):
-			return fmt.Errorf(errDirectoryDoesNotExist, downloadDir)
+			return nil, fmt.Errorf(errDirectoryDoesNotExist, options.DownloadDir)
 		default:
-			return fmt.Errorf("failed to add torrent: %w", err)
+			return nil, fmt.Errorf("failed to add torrent: %w", err)
 		}
 	}
 
-	return nil
+	return result, nil
 }
 
 // AddFile добавляет новый торрент из файла
-func (c *TransmissionClient) AddFile(filepath string, downloadDir string) error {
-	if downloadDir != "" {
-		if err := c.ValidateDownloadPath(downloadDir); err != nil {
-			return fmt.Errorf("invalid download directory: %w", err)
-		}
-	}
-
-	metainfo, err := os.ReadFile(filepath)
+func (c *TransmissionClient) AddFile(ctx context.Context, filepath string, options domain.AddTorrentOptions) (*domain.AddTorrentResult, error) {
+	payload, err := newAddPayload(options)
 	if err != nil {
-		return fmt.Errorf("failed to read torrent file: %w", err)
+		return nil, err
 	}
 
-	metainfoB64 := base64.StdEncoding.EncodeToString(metainfo)
-	payload := transmissionrpc.TorrentAddPayload{
-		MetaInfo: &metainfoB64,
-	}
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
 
-	if downloadDir != "" {
-		payload.DownloadDir = &downloadDir
+	data, err := os.ReadFile(filepath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read torrent file: %w", err)
 	}
 
-	_, err = c.client.TorrentAdd(c.ctx, payload)
+	metainfoB64 := base64.StdEncoding.EncodeToString(data)
+	payload.MetaInfo = &metainfoB64
+
+	result, err := c.addTorrent(ctx, payload, parseTorrentData(data), options.MergeTrackers)
 	if err != nil {
 		errStr := err.Error()
 		switch {
 		case strings.Contains(errStr, errPermissionDenied):
-			return fmt.Errorf(errPermissionDeniedForPath, downloadDir)
+			return nil, fmt.Errorf(errPermissionDeniedForPath, options.DownloadDir)
 		case strings.Contains(errStr, errNoSuchFileOrDirectory):
-			return fmt.Errorf(errDirectoryDoesNotExist, downloadDir)
+			return nil, fmt.Errorf(errDirectoryDoesNotExist, options.DownloadDir)
 		default:
-			return fmt.Errorf("failed to add torrent from file: %w", err)
+			return nil, fmt.Errorf("failed to add torrent from file: %w", err)
 		}
 	}
 
-	return nil
+	return result, nil
 }
 
 // addFromBase64 обрабатывает base64-закодированный торрент файл
-func (c *TransmissionClient) addFromBase64(dataUrl string, downloadDir string) error {
+func (c *TransmissionClient) addFromBase64(ctx context.Context, dataUrl string, payload transmissionrpc.TorrentAddPayload, mergeTrackers bool) (*domain.AddTorrentResult, error) {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
 	parts := strings.Split(dataUrl, ",")
 	if len(parts) != 2 {
-		return fmt.Errorf("invalid data URL format")
+		return nil, fmt.Errorf("invalid data URL format")
 	}
 
 	data, err := base64.StdEncoding.DecodeString(parts[1])
 	if err != nil {
-		return fmt.Errorf("failed to decode base64 data: %w", err)
+		return nil, fmt.Errorf("failed to decode base64 data: %w", err)
 	}
 
 	metainfoB64 := base64.StdEncoding.EncodeToString(data)
-	payload := transmissionrpc.TorrentAddPayload{
-		MetaInfo: &metainfoB64,
-	}
+	payload.MetaInfo = &metainfoB64
 
-	if downloadDir != "" {
-		payload.DownloadDir = &downloadDir
+	result, err := c.addTorrent(ctx, payload, parseTorrentData(data), mergeTrackers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add torrent: %w", err)
 	}
 
-	_, err = c.client.TorrentAdd(c.ctx, payload)
+	return result, nil
+}
+
+// newAddPayload переносит параметры добавления в запрос torrent-add.
+// Незаданные параметры не отправляются, чтобы действовали настройки демона.
+func newAddPayload(options domain.AddTorrentOptions) (transmissionrpc.TorrentAddPayload, error) {
+	var payload transmissionrpc.TorrentAddPayload
+
+	labels, err := normalizeLabels(options.Labels)
 	if err != nil {
-		return fmt.Errorf("failed to add torrent: %w", err)
+		return payload, err
+	}
+	if len(labels) > 0 {
+		payload.Labels = labels
 	}
 
-	return nil
+	if options.PeerLimit < 0 {
+		return payload, &LocalizedError{key: "errors.invalidPeerLimit"}
+	}
+	if options.PeerLimit > 0 {
+		payload.PeerLimit = &options.PeerLimit
+	}
+
+	if options.BandwidthPriority != "" {
+		priority, err := bandwidthPriorityValue(options.BandwidthPriority)
+		if err != nil {
+			return payload, err
+		}
+		payload.BandwidthPriority = &priority
+	}
+
+	if options.DownloadDir != "" {
+		payload.DownloadDir = &options.DownloadDir
+	}
+	payload.Paused = options.Paused
+	if options.Cookies != "" {
+		payload.Cookies = &options.Cookies
+	}
+
+	// Индекс в обоих списках дает неопределенный результат на стороне демона
+	if overlaps(options.FilesWanted, options.FilesUnwanted) {
+		return payload, &LocalizedError{key: "errors.conflictingFileSelection"}
+	}
+	if overlaps(options.PriorityHigh, options.PriorityLow) {
+		return payload, &LocalizedError{key: "errors.conflictingFilePriority"}
+	}
+
+	payload.FilesWanted = nonEmpty(options.FilesWanted)
+	payload.FilesUnwanted = nonEmpty(options.FilesUnwanted)
+	payload.PriorityHigh = nonEmpty(options.PriorityHigh)
+	payload.PriorityLow = nonEmpty(options.PriorityLow)
+
+	return payload, nil
+}
+
+// bandwidthPriorityValue преобразует приоритет в значение Transmission (-1, 0, 1)
+func bandwidthPriorityValue(priority domain.BandwidthPriority) (int64, error) {
+	switch priority {
+	case domain.BandwidthPriorityHigh:
+		return 1, nil
+	case domain.BandwidthPriorityNormal:
+		return 0, nil
+	case domain.BandwidthPriorityLow:
+		return -1, nil
+	default:
+		return 0, &LocalizedError{key: "errors.invalidBandwidthPriority"}
+	}
+}
+
+// overlaps проверяет, есть ли в списках общие индексы
+func overlaps(a, b []int64) bool {
+	for _, id := range a {
+		if slices.Contains(b, id) {
+			return true
+		}
+	}
+	return false
+}
+
+// nonEmpty возвращает nil для пустого списка, чтобы поле не попало в запрос
+func nonEmpty(ids []int64) []int64 {
+	if len(ids) == 0 {
+		return nil
+	}
+	return ids
 }
 
 // Remove удаляет торрент
-func (c *TransmissionClient) Remove(id int64, deleteData bool) error {
+func (c *TransmissionClient) Remove(ctx context.Context, id int64, deleteData bool) error {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
 	payload := transmissionrpc.TorrentRemovePayload{
 		IDs:             []int64{id},
 		DeleteLocalData: deleteData,
 	}
-	err := c.client.TorrentRemove(c.ctx, payload)
+	err := c.client.TorrentRemove(ctx, payload)
 	if err != nil {
 		return fmt.Errorf("failed to remove torrent: %w", err)
 	}
@@ -204,8 +342,11 @@ func (c *TransmissionClient) Remove(id int64, deleteData bool) error {
 }
 
 // Start запускает торренты
-func (c *TransmissionClient) Start(ids []int64) error {
-	err := c.client.TorrentStartIDs(c.ctx, ids)
+func (c *TransmissionClient) Start(ctx context.Context, ids []int64) error {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	err := c.client.TorrentStartIDs(ctx, ids)
 	if err != nil {
 		return fmt.Errorf("failed to start torrents: %w", err)
 	}
@@ -213,8 +354,11 @@ func (c *TransmissionClient) Start(ids []int64) error {
 }
 
 // Stop останавливает торренты
-func (c *TransmissionClient) Stop(ids []int64) error {
-	err := c.client.TorrentStopIDs(c.ctx, ids)
+func (c *TransmissionClient) Stop(ctx context.Context, ids []int64) error {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	err := c.client.TorrentStopIDs(ctx, ids)
 	if err != nil {
 		return fmt.Errorf("failed to stop torrents: %w", err)
 	}
@@ -222,7 +366,10 @@ func (c *TransmissionClient) Stop(ids []int64) error {
 }
 
 // SetTorrentSpeedLimit устанавливает ограничение скорости для торрентов
-func (c *TransmissionClient) SetTorrentSpeedLimit(ids []int64, downloadLimit int64, uploadLimit int64) error {
+func (c *TransmissionClient) SetTorrentSpeedLimit(ctx context.Context, ids []int64, downloadLimit int64, uploadLimit int64) error {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
 	args := transmissionrpc.TorrentSetPayload{
 		IDs:             ids,
 		DownloadLimited: &[]bool{downloadLimit > 0}[0],
@@ -236,27 +383,44 @@ func (c *TransmissionClient) SetTorrentSpeedLimit(ids []int64, downloadLimit int
 		args.UploadLimit = &[]int64{uploadLimit}[0]
 	}
 
-	return c.client.TorrentSet(c.ctx, args)
+	return c.client.TorrentSet(ctx, args)
 }
 
 // SetSpeedLimitFromConfig устанавливает ограничение скорости из конфигурации
-func (c *TransmissionClient) SetSpeedLimitFromConfig(ids []int64, config domain.Config, isSlowMode bool) error {
+func (c *TransmissionClient) SetSpeedLimitFromConfig(ctx context.Context, ids []int64, config domain.Config, isSlowMode bool) error {
 	if isSlowMode {
 		speedLimit := convertSpeedToKBps(config.SlowSpeedLimit, config.SlowSpeedUnit)
-		return c.SetTorrentSpeedLimit(ids, speedLimit, speedLimit)
+		return c.SetTorrentSpeedLimit(ctx, ids, speedLimit, speedLimit)
 	}
-	return c.SetTorrentSpeedLimit(ids, 0, 0)
+	return c.SetTorrentSpeedLimit(ctx, ids, 0, 0)
 }
 
 // VerifyTorrent запускает процесс проверки целостности данных торрента
-func (c *TransmissionClient) VerifyTorrent(id int64) error {
-	err := c.client.TorrentVerifyIDs(c.ctx, []int64{id})
+func (c *TransmissionClient) VerifyTorrent(ctx context.Context, id int64) error {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	err := c.client.TorrentVerifyIDs(ctx, []int64{id})
 	if err != nil {
 		return fmt.Errorf("failed to verify torrent: %w", err)
 	}
 	return nil
 }
 
+// MoveTorrents задает торрентам новое расположение данных. Если move равен true,
+// Transmission переносит файлы, иначе ищет уже существующие данные в newLocation
+func (c *TransmissionClient) MoveTorrents(ctx context.Context, ids []int64, newLocation string, move bool) error {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	for _, id := range ids {
+		if err := c.client.TorrentSetLocation(ctx, id, newLocation, move); err != nil {
+			return fmt.Errorf("failed to move torrent %d: %w", id, err)
+		}
+	}
+	return nil
+}
+
 // mapStatus преобразует статус торрента
 func mapStatus(status transmissionrpc.TorrentStatus, torrent transmissionrpc.Torrent) domain.TorrentStatus {
 	if status == transmissionrpc.TorrentStatusStopped && torrent.PercentDone != nil && *torrent.PercentDone == 1.0 {
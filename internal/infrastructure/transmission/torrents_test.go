@@ -0,0 +1,63 @@
+package transmission
+
+import (
+	"testing"
+	"transmission-client-go/internal/domain"
+)
+
+func TestNewAddPayload(t *testing.T) {
+	paused, started := true, false
+
+	tests := []struct {
+		name       string
+		options    domain.AddTorrentOptions
+		wantErr    string
+		wantPaused *bool
+	}{
+		{name: "daemon default start state", options: domain.AddTorrentOptions{}},
+		{name: "explicit pause", options: domain.AddTorrentOptions{Paused: &paused}, wantPaused: &paused},
+		{name: "explicit start", options: domain.AddTorrentOptions{Paused: &started}, wantPaused: &started},
+		{name: "disjoint file lists", options: domain.AddTorrentOptions{
+			FilesWanted: []int64{0, 1}, FilesUnwanted: []int64{2}, PriorityHigh: []int64{0}, PriorityLow: []int64{1},
+		}},
+		{
+			name:    "file both wanted and unwanted",
+			options: domain.AddTorrentOptions{FilesWanted: []int64{0, 1}, FilesUnwanted: []int64{1}},
+			wantErr: "errors.conflictingFileSelection",
+		},
+		{
+			name:    "file with both priorities",
+			options: domain.AddTorrentOptions{PriorityHigh: []int64{3}, PriorityLow: []int64{2, 3}},
+			wantErr: "errors.conflictingFilePriority",
+		},
+		{
+			name:    "unknown bandwidth priority",
+			options: domain.AddTorrentOptions{BandwidthPriority: "urgent"},
+			wantErr: "errors.invalidBandwidthPriority",
+		},
+		{
+			name:    "negative peer limit",
+			options: domain.AddTorrentOptions{PeerLimit: -1},
+			wantErr: "errors.invalidPeerLimit",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, err := newAddPayload(tt.options)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("newAddPayload() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("newAddPayload() error = %v", err)
+			}
+			if (payload.Paused == nil) != (tt.wantPaused == nil) ||
+				(payload.Paused != nil && *payload.Paused != *tt.wantPaused) {
+				t.Errorf("Paused = %v, want %v", payload.Paused, tt.wantPaused)
+			}
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package transmission
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"transmission-client-go/internal/domain"
+
+	"github.com/hekmon/transmissionrpc/v3"
+)
+
+// trackerListRPCVersion версия RPC (Transmission 4.0), начиная с которой трекеры
+// редактируются полем trackerList вместо trackerAdd/trackerReplace/trackerRemove
+const trackerListRPCVersion = 17
+
+// legacyTrackerSetRequest запрос torrent-set для демонов без поддержки trackerList
+type legacyTrackerSetRequest struct {
+	IDs            []int64  `json:"ids"`
+	TrackerAdd     []string `json:"trackerAdd,omitempty"`
+	TrackerRemove  []int64  `json:"trackerRemove,omitempty"`
+	TrackerReplace []any    `json:"trackerReplace,omitempty"` // Пары [id, url]
+}
+
+// GetTorrentTrackers возвращает трекеры торрента со статистикой анонсов
+func (c *TransmissionClient) GetTorrentTrackers(ctx context.Context, id int64) ([]domain.TorrentTracker, error) {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	torrents, err := c.client.TorrentGet(ctx, []string{"id", "trackerStats"}, []int64{id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get torrent trackers: %w", err)
+	}
+	if len(torrents) == 0 {
+		return nil, fmt.Errorf("torrent not found")
+	}
+
+	stats := torrents[0].TrackerStats
+	result := make([]domain.TorrentTracker, len(stats))
+	for i, ts := range stats {
+		result[i] = convertTrackerStats(ts)
+	}
+	return result, nil
+}
+
+// convertTrackerStats преобразует статистику трекера в доменную модель
+func convertTrackerStats(ts transmissionrpc.TrackerStats) domain.TorrentTracker {
+	tracker := domain.TorrentTracker{
+		ID:                    ts.ID,
+		Announce:              ts.Announce,
+		Scrape:                ts.Scrape,
+		SiteName:              ts.SiteName,
+		Host:                  ts.Host,
+		Tier:                  ts.Tier,
+		IsBackup:              ts.IsBackup,
+		LastAnnounceSucceeded: ts.LastAnnounceSucceeded,
+		LastAnnounceTimedOut:  ts.LastAnnounceTimedOut,
+		LastAnnounceResult:    ts.LastAnnounceResult,
+		LastAnnouncePeerCount: ts.LastAnnouncePeerCount,
+		NextAnnounceTime:      ts.NextAnnounceTime.Unix(),
+		LastScrapeSucceeded:   ts.LastScrapeSucceeded,
+		LastScrapeTimedOut:    ts.LastScrapeTimedOut,
+		LastScrapeResult:      ts.LastScrapeResult,
+		NextScrapeTime:        ts.NextScrapeTime.Unix(),
+		SeederCount:           ts.SeederCount,
+		LeecherCount:          ts.LeecherCount,
+		DownloadCount:         ts.DownloadCount,
+	}
+
+	if ts.HasAnnounced {
+		tracker.LastAnnounceTime = ts.LastAnnounceTime.Unix()
+		if !ts.LastAnnounceSucceeded {
+			tracker.Error = ts.LastAnnounceResult
+		}
+	}
+	if ts.HasScraped {
+		tracker.LastScrapeTime = ts.LastScrapeTime.Unix()
+		if !ts.LastScrapeSucceeded && tracker.Error == "" {
+			tracker.Error = ts.LastScrapeResult
+		}
+	}
+
+	return tracker
+}
+
+// ValidateAnnounceURL проверяет, что адрес трекера имеет поддерживаемую схему
+func ValidateAnnounceURL(announceURL string) error {
+	parsed, err := url.Parse(strings.TrimSpace(announceURL))
+	if err != nil || parsed.Host == "" {
+		return &LocalizedError{key: "errors.invalidTrackerURL"}
+	}
+
+	switch parsed.Scheme {
+	case "http", "https", "udp", "ws", "wss":
+		return nil
+	default:
+		return &LocalizedError{key: "errors.invalidTrackerURL"}
+	}
+}
+
+// AddTrackers добавляет трекеры к торренту, каждый в отдельный уровень
+func (c *TransmissionClient) AddTrackers(ctx context.Context, id int64, announceURLs []string) error {
+	for _, announceURL := range announceURLs {
+		if err := ValidateAnnounceURL(announceURL); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	err := c.updateTrackers(ctx, id,
+		[]legacyTrackerSetRequest{{TrackerAdd: announceURLs}},
+		func(trackers []transmissionrpc.Tracker) ([]transmissionrpc.Tracker, error) {
+			nextTier := nextTrackerTier(trackers)
+			for _, announceURL := range announceURLs {
+				trackers = append(trackers, transmissionrpc.Tracker{Announce: announceURL, Tier: nextTier})
+				nextTier++
+			}
+			return trackers, nil
+		})
+	if err != nil {
+		return fmt.Errorf("failed to add trackers: %w", err)
+	}
+	return nil
+}
+
+// ReplaceTracker заменяет адрес анонса трекера
+func (c *TransmissionClient) ReplaceTracker(ctx context.Context, id int64, trackerID int64, announceURL string) error {
+	return c.ReplaceTrackers(ctx, id, []domain.TrackerReplacement{{TrackerID: trackerID, NewURL: announceURL}})
+}
+
+// ReplaceTrackers заменяет адреса нескольких трекеров торрента.
+// На новых демонах весь список отправляется одним trackerList, поэтому ID
+// остальных трекеров не устаревают между заменами. ID трекеров меняются после
+// каждого редактирования списка, поэтому замена с OldURL, который уже не совпадает
+// с текущим адресом, отклоняется целиком.
+func (c *TransmissionClient) ReplaceTrackers(ctx context.Context, id int64, replacements []domain.TrackerReplacement) error {
+	for _, replacement := range replacements {
+		if err := ValidateAnnounceURL(replacement.NewURL); err != nil {
+			return err
+		}
+	}
+
+	// Старые демоны принимают по одной замене на запрос
+	sorted := slices.SortedFunc(slices.Values(replacements), func(a, b domain.TrackerReplacement) int {
+		return cmp.Compare(a.TrackerID, b.TrackerID)
+	})
+	legacy := make([]legacyTrackerSetRequest, 0, len(sorted))
+	for _, replacement := range sorted {
+		legacy = append(legacy, legacyTrackerSetRequest{
+			TrackerReplace: []any{replacement.TrackerID, replacement.NewURL},
+		})
+	}
+
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	err := c.updateTrackers(ctx, id, legacy,
+		func(trackers []transmissionrpc.Tracker) ([]transmissionrpc.Tracker, error) {
+			for _, replacement := range replacements {
+				i := slices.IndexFunc(trackers, func(t transmissionrpc.Tracker) bool {
+					return t.ID == replacement.TrackerID
+				})
+				if i < 0 {
+					return nil, &LocalizedError{key: "errors.trackerNotFound"}
+				}
+				if replacement.OldURL != "" && trackers[i].Announce != replacement.OldURL {
+					return nil, &LocalizedError{key: "errors.trackerChanged"}
+				}
+				trackers[i].Announce = replacement.NewURL
+			}
+			return trackers, nil
+		})
+	if err != nil {
+		return fmt.Errorf("failed to replace tracker: %w", err)
+	}
+	return nil
+}
+
+// RemoveTrackers удаляет трекеры торрента по их ID
+func (c *TransmissionClient) RemoveTrackers(ctx context.Context, id int64, trackerIDs []int64) error {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	err := c.updateTrackers(ctx, id,
+		[]legacyTrackerSetRequest{{TrackerRemove: trackerIDs}},
+		func(trackers []transmissionrpc.Tracker) ([]transmissionrpc.Tracker, error) {
+			for _, trackerID := range trackerIDs {
+				if !slices.ContainsFunc(trackers, func(t transmissionrpc.Tracker) bool { return t.ID == trackerID }) {
+					return nil, &LocalizedError{key: "errors.trackerNotFound"}
+				}
+			}
+			return slices.DeleteFunc(trackers, func(t transmissionrpc.Tracker) bool {
+				return slices.Contains(trackerIDs, t.ID)
+			}), nil
+		})
+	if err != nil {
+		return fmt.Errorf("failed to remove trackers: %w", err)
+	}
+	return nil
+}
+
+// updateTrackers изменяет трекеры торрента: на новых демонах через trackerList,
+// на старых через trackerAdd/trackerReplace/trackerRemove.
+// edit вызывается для текущего списка трекеров в обоих случаях, поэтому его ошибка
+// (например, неизвестный ID трекера) прерывает изменение до отправки запросов.
+func (c *TransmissionClient) updateTrackers(
+	ctx context.Context,
+	id int64,
+	legacy []legacyTrackerSetRequest,
+	edit func([]transmissionrpc.Tracker) ([]transmissionrpc.Tracker, error),
+) error {
+	version, err := c.getRPCVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	torrents, err := c.client.TorrentGet(ctx, []string{"id", "trackers"}, []int64{id})
+	if err != nil {
+		return err
+	}
+	if len(torrents) == 0 {
+		return fmt.Errorf("torrent not found")
+	}
+
+	trackers, err := edit(slices.Clone(torrents[0].Trackers))
+	if err != nil {
+		return err
+	}
+
+	if version < trackerListRPCVersion {
+		for _, request := range legacy {
+			request.IDs = []int64{id}
+			if err := c.rpc.call(ctx, "torrent-set", request, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return c.client.TorrentSet(ctx, transmissionrpc.TorrentSetPayload{
+		IDs:         []int64{id},
+		TrackerList: buildTrackerList(trackers),
+	})
+}
+
+// nextTrackerTier возвращает номер уровня, следующего за последним
+func nextTrackerTier(trackers []transmissionrpc.Tracker) int64 {
+	var next int64
+	for _, t := range trackers {
+		if t.Tier >= next {
+			next = t.Tier + 1
+		}
+	}
+	return next
+}
+
+// buildTrackerList формирует trackerList: по адресу на строку, уровни разделены пустой строкой
+func buildTrackerList(trackers []transmissionrpc.Tracker) []string {
+	sorted := slices.Clone(trackers)
+	slices.SortStableFunc(sorted, func(a, b transmissionrpc.Tracker) int {
+		return cmp.Compare(a.Tier, b.Tier)
+	})
+
+	list := make([]string, 0, len(sorted)*2)
+	for i, t := range sorted {
+		if i > 0 && t.Tier != sorted[i-1].Tier {
+			list = append(list, "")
+		}
+		list = append(list, t.Announce)
+	}
+	return list
+}
@@ -0,0 +1,94 @@
+package transmission
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"transmission-client-go/internal/domain"
+)
+
+// trackerDaemon сервер с одним торрентом и двумя трекерами заданной версии RPC
+func trackerDaemon(t *testing.T, rpcVersion int) (*TransmissionClient, *fakeDaemon) {
+	return newFakeClient(t, map[string]rpcHandler{
+		"session-get": func(map[string]any) (string, any) {
+			return "success", map[string]any{"rpc-version": rpcVersion}
+		},
+		"torrent-get": func(map[string]any) (string, any) {
+			return torrentsReply(map[string]any{"id": 1, "trackers": []any{
+				map[string]any{"id": 0, "announce": "http://a/announce", "tier": 0},
+				map[string]any{"id": 1, "announce": "http://b/announce", "tier": 1},
+			}})
+		},
+	})
+}
+
+func TestUpdateTrackersUnknownID(t *testing.T) {
+	for _, version := range []int{trackerListRPCVersion - 1, trackerListRPCVersion} {
+		client, daemon := trackerDaemon(t, version)
+		ctx := context.Background()
+
+		var locErr *LocalizedError
+		if err := client.RemoveTrackers(ctx, 1, []int64{7}); !errors.As(err, &locErr) || locErr.Error() != "errors.trackerNotFound" {
+			t.Errorf("rpc %d: RemoveTrackers() error = %v, want errors.trackerNotFound", version, err)
+		}
+		if err := client.ReplaceTracker(ctx, 1, 7, "http://c/announce"); !errors.As(err, &locErr) || locErr.Error() != "errors.trackerNotFound" {
+			t.Errorf("rpc %d: ReplaceTracker() error = %v, want errors.trackerNotFound", version, err)
+		}
+		if sets := daemon.methodCalls("torrent-set"); len(sets) != 0 {
+			t.Errorf("rpc %d: torrent-set sent for unknown tracker: %v", version, sets)
+		}
+	}
+}
+
+func TestUpdateTrackersLegacy(t *testing.T) {
+	client, daemon := trackerDaemon(t, trackerListRPCVersion-1)
+
+	if err := client.RemoveTrackers(context.Background(), 1, []int64{1}); err != nil {
+		t.Fatalf("RemoveTrackers() error = %v", err)
+	}
+
+	sets := daemon.methodCalls("torrent-set")
+	if len(sets) != 1 {
+		t.Fatalf("torrent-set calls = %d, want 1", len(sets))
+	}
+	if removed, _ := sets[0]["trackerRemove"].([]any); len(removed) != 1 || removed[0] != float64(1) {
+		t.Errorf("trackerRemove = %v, want [1]", sets[0]["trackerRemove"])
+	}
+	if _, ok := sets[0]["trackerList"]; ok {
+		t.Errorf("legacy daemon received trackerList")
+	}
+}
+
+func TestReplaceTrackersStaleOldURL(t *testing.T) {
+	for _, version := range []int{trackerListRPCVersion - 1, trackerListRPCVersion} {
+		client, daemon := trackerDaemon(t, version)
+
+		// Трекер 1 после просмотра получил другой адрес
+		err := client.ReplaceTrackers(context.Background(), 1, []domain.TrackerReplacement{
+			{TrackerID: 0, OldURL: "http://a/announce", NewURL: "https://a/announce"},
+			{TrackerID: 1, OldURL: "http://old/announce", NewURL: "https://old/announce"},
+		})
+		var locErr *LocalizedError
+		if !errors.As(err, &locErr) || locErr.Error() != "errors.trackerChanged" {
+			t.Errorf("rpc %d: ReplaceTrackers() error = %v, want errors.trackerChanged", version, err)
+		}
+		if sets := daemon.methodCalls("torrent-set"); len(sets) != 0 {
+			t.Errorf("rpc %d: torrent-set sent for a stale plan: %v", version, sets)
+		}
+	}
+}
+
+func TestReplaceTrackersInvalidURL(t *testing.T) {
+	client, daemon := trackerDaemon(t, trackerListRPCVersion)
+
+	err := client.ReplaceTrackers(context.Background(), 1, []domain.TrackerReplacement{
+		{TrackerID: 0, OldURL: "http://a/announce", NewURL: "ftp://a/announce"},
+	})
+	var locErr *LocalizedError
+	if !errors.As(err, &locErr) || locErr.Error() != "errors.invalidTrackerURL" {
+		t.Errorf("ReplaceTrackers() error = %v, want errors.invalidTrackerURL", err)
+	}
+	if calls := len(daemon.methodCalls("torrent-set")); calls != 0 {
+		t.Errorf("torrent-set calls = %d, want 0", calls)
+	}
+}
@@ -3,6 +3,7 @@ package transmission
 import (
 	"fmt"
 	"math"
+	"transmission-client-go/internal/domain"
 
 	"github.com/hekmon/transmissionrpc/v3"
 )
@@ -51,12 +52,17 @@ func getTorrentSizes(t transmissionrpc.Torrent) (total uint64, downloaded uint64
 	return total, downloaded
 }
 
-// getPeerInfo возвращает информацию о пирах
-func getPeerInfo(t *transmissionrpc.Torrent) (peersConnected int, seedsTotal int, peersTotal int) {
+// getPeerInfo возвращает информацию о пирах. Число подключенных сидов требует
+// массива пиров, поэтому в списке торрентов его нет: см. GetTorrentPeers
+func getPeerInfo(t *transmissionrpc.Torrent) (peersConnected int, peersSendingToUs int, seedsTotal int, peersTotal int) {
 	if t.PeersConnected != nil {
 		peersConnected = int(*t.PeersConnected)
 	}
 
+	if t.PeersSendingToUs != nil {
+		peersSendingToUs = int(*t.PeersSendingToUs)
+	}
+
 	if t.TrackerStats != nil {
 		for _, tracker := range t.TrackerStats {
 			seedsTotal += int(tracker.SeederCount)
@@ -67,6 +73,19 @@ func getPeerInfo(t *transmissionrpc.Torrent) (peersConnected int, seedsTotal int
 	return
 }
 
+// getTrackers возвращает адреса анонса трекеров торрента
+func getTrackers(t *transmissionrpc.Torrent) []domain.TrackerAnnounce {
+	trackers := make([]domain.TrackerAnnounce, len(t.TrackerStats))
+	for i, tracker := range t.TrackerStats {
+		trackers[i] = domain.TrackerAnnounce{
+			ID:       tracker.ID,
+			Announce: tracker.Announce,
+			Tier:     tracker.Tier,
+		}
+	}
+	return trackers
+}
+
 // getUploadInfo возвращает информацию о загрузке
 func getUploadInfo(t *transmissionrpc.Torrent) (uploadRatio float64, uploadedBytes int64) {
 	if t.UploadRatio != nil {
@@ -29,6 +29,7 @@ func main() {
 		},
 		BackgroundColour: &options.RGBA{R: 255, G: 255, B: 255, A: 1},
 		OnStartup:        app.startup,
+		OnShutdown:       app.shutdown,
 		Mac: &mac.Options{
 			About: &mac.AboutInfo{
 				Title:   "Remote Transmission Desktop Client",